@@ -0,0 +1,111 @@
+package streams
+
+import "hash/fnv"
+
+// Aggregator is an aggregate of elements, e.g. the elements of a window.
+// Aggregators are not safe for concurrent use. Partial aggregates of parallel
+// branches are combined with the Merge method of each aggregator.
+type Aggregator[T, R any] interface {
+	// Add adds an element to the aggregate.
+	Add(T)
+	// Result returns the current result of the aggregate.
+	Result() R
+}
+
+var (
+	_ Streamable = (*AggregateImpl[any, any])(nil)
+	_ Receivable = (*AggregateImpl[any, any])(nil)
+)
+
+// AggregateImpl adds each element to an aggregator and emits the result when the input is closed.
+type AggregateImpl[T, R any] struct {
+	agg Aggregator[T, R]
+	in  chan any
+	out chan any
+	canceler
+}
+
+// Aggregate returns a new operator that adds each element to the aggregator and emits the result when the input is closed.
+func Aggregate[T, R any](agg Aggregator[T, R]) *AggregateImpl[T, R] {
+	return NewAggregate(agg)
+}
+
+// NewAggregate returns a new operator that adds each element to the aggregator and emits the result when the input is closed.
+func NewAggregate[T, R any](agg Aggregator[T, R]) *AggregateImpl[T, R] {
+	a := &AggregateImpl[T, R]{
+		agg:      agg,
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
+	}
+
+	go a.attach()
+
+	return a
+}
+
+// To streams data to the sink and waits for it to complete.
+func (a *AggregateImpl[T, R]) To(sink Sinkable) error {
+	a.stream(sink)
+
+	err := sink.Wait()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// In returns the input channel.
+func (a *AggregateImpl[T, R]) In() chan<- any {
+	return a.in
+}
+
+// Out returns the output channel.
+func (a *AggregateImpl[T, R]) Out() <-chan any {
+	return a.out
+}
+
+// Pipe pipes the output channel to the input channel.
+func (a *AggregateImpl[T, R]) Pipe(c Operatable) Operatable {
+	go a.stream(c)
+	return c
+}
+
+func (a *AggregateImpl[T, R]) stream(r Receivable) {
+	done := doneOf(r)
+	for x := range a.out {
+		select {
+		case r.In() <- x:
+		case <-done:
+			a.cancel()
+		}
+	}
+
+	close(r.In())
+}
+
+func (a *AggregateImpl[T, R]) attach() {
+	for x := range a.in {
+		a.agg.Add(x.(T))
+	}
+
+	a.out <- a.agg.Result()
+	close(a.out)
+}
+
+// hash64 returns a well distributed 64-bit hash of a key.
+func hash64(key string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+
+	// finalizer of murmur3 to spread the bits of fnv
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+
+	return x
+}
@@ -0,0 +1,28 @@
+package streams_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/sinks"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregate(t *testing.T) {
+	in := make(chan any, 4)
+	out := make(chan any, 1)
+
+	channels.Channel([]string{"a", "b", "a", "c"}, in)
+	close(in)
+
+	topk := streams.NewTopK(1, strconv.Quote)
+
+	err := sources.NewChanSource(in).Pipe(streams.Aggregate(topk)).To(sinks.NewChanSink(out))
+	require.NoError(t, err)
+
+	output := channels.Slice[[]streams.TopKItem](out)
+	require.Equal(t, [][]streams.TopKItem{{{Key: `"a"`, Count: 2}}}, output)
+}
@@ -0,0 +1,216 @@
+package streams
+
+import (
+	"reflect"
+
+	"github.com/katallaxie/pkg/slices"
+)
+
+// AnyOutput selects the first output that is available to receive an element.
+const AnyOutput = -1
+
+// BalanceStrategy selects the output for an element.
+type BalanceStrategy interface {
+	// Select returns the index of the output for the element or AnyOutput.
+	Select(x any, n int) int
+}
+
+// BalanceFunc is an adapter to use a function as a balance strategy.
+type BalanceFunc func(x any, n int) int
+
+// Select returns the index of the output for the element.
+func (fn BalanceFunc) Select(x any, n int) int {
+	return fn(x, n)
+}
+
+// RoundRobin returns a strategy that selects the outputs in turn.
+func RoundRobin() BalanceStrategy {
+	next := 0
+
+	return BalanceFunc(func(_ any, n int) int {
+		i := next % n
+		next = i + 1
+
+		return i
+	})
+}
+
+// LeastBusy returns a strategy that selects the first output that is available to receive an element.
+func LeastBusy() BalanceStrategy {
+	return BalanceFunc(func(any, int) int {
+		return AnyOutput
+	})
+}
+
+// HashBy returns a strategy that selects the output by the hash of the key of an element.
+// Elements with the same key are always sent to the same output.
+// Elements of an unexpected type are sent to the first output.
+func HashBy[T any](fn KeyFunc[T]) BalanceStrategy {
+	return BalanceFunc(func(x any, n int) int {
+		v, ok := x.(T)
+		if !ok {
+			return 0
+		}
+
+		return hashKey(fn(v), n)
+	})
+}
+
+// hashKey maps a key to one of n buckets with jump consistent hashing,
+// so that only a minimal share of keys moves when n changes.
+// All keys map to the first bucket if n is not positive.
+func hashKey(key string, n int) int {
+	if n <= 1 {
+		return 0
+	}
+
+	h := hash64(key)
+
+	var b, j int64 = -1, 0
+	for j < int64(n) {
+		b = j
+		h = h*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((h>>33)+1)))
+	}
+
+	return int(b)
+}
+
+// Balance distributes the elements of a stream to n outputs so that each element is sent to exactly one output.
+// The strategy defaults to RoundRobin if nil. There is at least one output.
+func Balance(in Streamable, n int, strategy BalanceStrategy) []Operatable {
+	return NewBalancer(in, n, strategy, false).Outputs()
+}
+
+// Balancer distributes the elements of a stream to a number of outputs.
+type Balancer struct {
+	outs  []Operatable
+	order chan int
+}
+
+// NewBalancer returns a new balancer that distributes the elements of a stream to n outputs.
+// If ordered is set the balancer records the order of the elements for MergeOrdered.
+// There is at least one output.
+func NewBalancer(in Streamable, n int, strategy BalanceStrategy, ordered bool) *Balancer {
+	n = max(n, 1)
+
+	if strategy == nil {
+		strategy = RoundRobin()
+	}
+
+	b := &Balancer{
+		outs: make([]Operatable, n),
+	}
+
+	slices.ForEach(func(_ Operatable, i int) {
+		b.outs[i] = PassThrough()
+	}, b.outs...)
+
+	var order chan int
+	if ordered {
+		order = make(chan int)
+		b.order = unbounded(order)
+	}
+
+	go b.attach(in, strategy, order)
+
+	return b
+}
+
+// Outputs returns the outputs of the balancer.
+func (b *Balancer) Outputs() []Operatable {
+	return b.outs
+}
+
+// MergeOrdered merges the outputs of the workers into one stream in the original order of the elements.
+// Each worker must emit exactly one element per received element and the streams must be passed in the
+// order of the outputs. Without a recorded order the streams are merged as with Merge.
+func (b *Balancer) MergeOrdered(in ...Streamable) Operatable {
+	if b.order == nil {
+		return Merge(in...)
+	}
+
+	merged := NewPassThrough()
+
+	go func() {
+		for i := range b.order {
+			x, ok := <-in[i].Out()
+			if !ok {
+				continue
+			}
+
+			merged.In() <- x
+		}
+
+		close(merged.In())
+	}()
+
+	return merged
+}
+
+func (b *Balancer) attach(in Streamable, strategy BalanceStrategy, order chan<- int) {
+	cases := make([]reflect.SelectCase, len(b.outs))
+	for i, flow := range b.outs {
+		cases[i] = reflect.SelectCase{Dir: reflect.SelectSend, Chan: reflect.ValueOf(flow.In())}
+	}
+
+	for x := range in.Out() {
+		i := strategy.Select(x, len(b.outs))
+		if i == AnyOutput {
+			v := reflect.ValueOf(&x).Elem()
+			for j := range cases {
+				cases[j].Send = v
+			}
+
+			i, _, _ = reflect.Select(cases)
+		} else {
+			b.outs[i].In() <- x
+		}
+
+		if order != nil {
+			order <- i
+		}
+	}
+
+	if order != nil {
+		close(order)
+	}
+
+	for _, flow := range b.outs {
+		close(flow.In())
+	}
+}
+
+// unbounded buffers the values of a channel without limit so that the sender never blocks.
+func unbounded[T any](in <-chan T) chan T {
+	out := make(chan T)
+
+	go func() {
+		defer close(out)
+
+		var queue []T
+		for in != nil || len(queue) > 0 {
+			var send chan T
+			var next T
+
+			if len(queue) > 0 {
+				send = out
+				next = queue[0]
+			}
+
+			select {
+			case x, ok := <-in:
+				if !ok {
+					in = nil
+					continue
+				}
+
+				queue = append(queue, x)
+			case send <- next:
+				queue = queue[1:]
+			}
+		}
+	}()
+
+	return out
+}
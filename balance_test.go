@@ -0,0 +1,97 @@
+package streams_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/sinks"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalance(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy streams.BalanceStrategy
+		in       []any
+		expected map[string][]any
+	}{
+		{
+			name:     "round robin",
+			strategy: streams.RoundRobin(),
+			in:       []any{1, 2, 3, 4, 5},
+			expected: map[string][]any{"0": {1, 3, 5}, "1": {2, 4}},
+		},
+		{
+			name:     "hash by key",
+			strategy: streams.HashBy(func(x int) string { return strconv.Itoa(x % 2) }),
+			in:       []any{1, 2, 3, 4, 5},
+		},
+		{
+			name:     "least busy",
+			strategy: streams.LeastBusy(),
+			in:       []any{1, 2, 3, 4, 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := make(chan any, 5)
+			channels.Channel(tt.in, in)
+			close(in)
+
+			outputs := streams.Balance(sources.NewChanSource(in), 2, tt.strategy)
+			require.Len(t, outputs, 2)
+
+			named := map[string]streams.Operatable{"0": outputs[0], "1": outputs[1]}
+			res := collect(t, named)
+
+			assert.ElementsMatch(t, tt.in, append(res["0"], res["1"]...))
+			if tt.expected != nil {
+				assert.Equal(t, tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestBalanceHashAffinity(t *testing.T) {
+	in := make(chan any, 6)
+	channels.Channel([]any{1, 2, 3, 4, 5, 6}, in)
+	close(in)
+
+	outputs := streams.Balance(sources.NewChanSource(in), 3, streams.HashBy(func(x int) string { return strconv.Itoa(x % 2) }))
+	res := collect(t, map[string]streams.Operatable{"0": outputs[0], "1": outputs[1], "2": outputs[2]})
+
+	for _, elements := range res {
+		for _, x := range elements {
+			assert.Equal(t, elements[0].(int)%2, x.(int)%2)
+		}
+	}
+}
+
+func TestBalancerMergeOrdered(t *testing.T) {
+	in := make(chan any, 10)
+	out := make(chan any, 10)
+
+	channels.Channel([]any{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, in)
+	close(in)
+
+	b := streams.NewBalancer(sources.NewChanSource(in), 3, streams.LeastBusy(), true)
+
+	workers := make([]streams.Streamable, 0, 3)
+	for i, flow := range b.Outputs() {
+		workers = append(workers, flow.Pipe(streams.Map(func(x int) int {
+			time.Sleep(time.Duration(3-i) * time.Millisecond)
+			return x * 10
+		})))
+	}
+
+	err := b.MergeOrdered(workers...).To(sinks.NewChanSink(out))
+	require.NoError(t, err)
+
+	require.Equal(t, []int{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}, channels.Slice[int](out))
+}
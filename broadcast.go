@@ -0,0 +1,168 @@
+package streams
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// SlowConsumerPolicy decides what happens when a branch of a broadcast can not keep up.
+type SlowConsumerPolicy int
+
+const (
+	// BlockSlowConsumer blocks all branches until the slow branch received the element.
+	BlockSlowConsumer SlowConsumerPolicy = iota
+	// DropSlowConsumer drops the element for the slow branch.
+	DropSlowConsumer
+	// DetachSlowConsumer closes the slow branch if it did not receive the element within the timeout.
+	DetachSlowConsumer
+)
+
+// BroadcastConfig holds the configuration for a broadcast.
+type BroadcastConfig struct {
+	// Buffer is the number of elements buffered per branch.
+	Buffer int
+	// Policy decides what happens when the buffer of a branch is full.
+	Policy SlowConsumerPolicy
+	// DetachTimeout is the time to wait for a full branch before it is detached.
+	DetachTimeout time.Duration
+}
+
+// DefaultBroadcastConfig returns a default broadcast configuration.
+func DefaultBroadcastConfig() *BroadcastConfig {
+	return &BroadcastConfig{
+		Buffer:        64,
+		Policy:        BlockSlowConsumer,
+		DetachTimeout: time.Second,
+	}
+}
+
+// BranchStats are the metrics of a branch of a broadcast.
+type BranchStats struct {
+	// Lag is the number of elements buffered for the branch.
+	Lag int
+	// Delivered is the number of elements received by the branch.
+	Delivered uint64
+	// Dropped is the number of elements dropped for the branch.
+	Dropped uint64
+	// Detached reports if the branch has been detached.
+	Detached bool
+}
+
+type branch struct {
+	buf       chan any
+	out       Operatable
+	delivered atomic.Uint64
+	dropped   atomic.Uint64
+	detached  atomic.Bool
+}
+
+// Broadcaster fans out a stream to multiple branches with a buffer per branch,
+// so that a slow branch does not stall the other branches.
+type Broadcaster struct {
+	cfg      *BroadcastConfig
+	branches []*branch
+}
+
+// NewBroadcaster returns a new broadcaster that fans out a stream to num branches.
+func NewBroadcaster(in Streamable, num int, cfg *BroadcastConfig) *Broadcaster {
+	b := &Broadcaster{
+		cfg:      cfg,
+		branches: make([]*branch, num),
+	}
+
+	for i := range b.branches {
+		br := &branch{
+			buf: make(chan any, cfg.Buffer),
+			out: PassThrough(),
+		}
+		b.branches[i] = br
+
+		go br.attach()
+	}
+
+	go b.attach(in)
+
+	return b
+}
+
+// Outputs returns the branches of the broadcaster.
+func (b *Broadcaster) Outputs() []Operatable {
+	out := make([]Operatable, len(b.branches))
+	for i, br := range b.branches {
+		out[i] = br.out
+	}
+
+	return out
+}
+
+// Stats returns the metrics of each branch.
+func (b *Broadcaster) Stats() []BranchStats {
+	stats := make([]BranchStats, len(b.branches))
+	for i, br := range b.branches {
+		stats[i] = BranchStats{
+			Lag:       len(br.buf),
+			Delivered: br.delivered.Load(),
+			Dropped:   br.dropped.Load(),
+			Detached:  br.detached.Load(),
+		}
+	}
+
+	return stats
+}
+
+func (b *Broadcaster) attach(in Streamable) {
+	for x := range in.Out() {
+		for _, br := range b.branches {
+			if br.detached.Load() {
+				continue
+			}
+
+			b.send(br, x)
+		}
+	}
+
+	for _, br := range b.branches {
+		if !br.detached.Load() {
+			close(br.buf)
+		}
+	}
+}
+
+func (b *Broadcaster) send(br *branch, x any) {
+	switch b.cfg.Policy {
+	case DropSlowConsumer:
+		select {
+		case br.buf <- x:
+		default:
+			br.dropped.Add(1)
+		}
+	case DetachSlowConsumer:
+		select {
+		case br.buf <- x:
+			return
+		default:
+		}
+
+		timer := time.NewTimer(b.cfg.DetachTimeout)
+		defer timer.Stop()
+
+		select {
+		case br.buf <- x:
+		case <-timer.C:
+			br.dropped.Add(1)
+			br.detached.Store(true)
+			close(br.buf)
+		}
+	case BlockSlowConsumer:
+		br.buf <- x
+	}
+}
+
+func (br *branch) attach() {
+	for x := range br.buf {
+		br.out.In() <- x
+		br.delivered.Add(1)
+	}
+
+	close(br.out.In())
+}
@@ -0,0 +1,83 @@
+package streams_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/sinks"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcasterBlock(t *testing.T) {
+	in := make(chan any, 5)
+	channels.Channel([]int{1, 2, 3, 4, 5}, in)
+	close(in)
+
+	b := streams.NewBroadcaster(sources.NewChanSource(in), 2, &streams.BroadcastConfig{Buffer: 1, Policy: streams.BlockSlowConsumer})
+	res := collect(t, map[string]streams.Operatable{"0": b.Outputs()[0], "1": b.Outputs()[1]})
+
+	assert.Equal(t, []any{1, 2, 3, 4, 5}, res["0"])
+	assert.Equal(t, []any{1, 2, 3, 4, 5}, res["1"])
+
+	for _, stats := range b.Stats() {
+		assert.Equal(t, uint64(5), stats.Delivered)
+		assert.Equal(t, uint64(0), stats.Dropped)
+	}
+}
+
+func TestBroadcasterSlowConsumer(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *streams.BroadcastConfig
+		detached bool
+	}{
+		{
+			name: "drop",
+			cfg:  &streams.BroadcastConfig{Buffer: 1, Policy: streams.DropSlowConsumer},
+		},
+		{
+			name:     "detach",
+			cfg:      &streams.BroadcastConfig{Buffer: 1, Policy: streams.DetachSlowConsumer, DetachTimeout: time.Millisecond},
+			detached: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := make(chan any)
+
+			b := streams.NewBroadcaster(sources.NewChanSource(in), 2, tt.cfg)
+			outputs := b.Outputs()
+
+			for i := 1; i <= 10; i++ {
+				in <- i
+				require.Equal(t, i, <-outputs[0].Out())
+			}
+			close(in)
+
+			_, ok := <-outputs[0].Out()
+			require.False(t, ok)
+
+			slow := make(chan any, 10)
+			err := outputs[1].To(sinks.NewChanSink(slow))
+			require.NoError(t, err)
+
+			output := channels.Slice[int](slow)
+			assert.Less(t, len(output), 10)
+
+			stats := b.Stats()
+			assert.Equal(t, uint64(10), stats[0].Delivered)
+			assert.Equal(t, uint64(len(output)), stats[1].Delivered)
+			assert.Equal(t, tt.detached, stats[1].Detached)
+			assert.Equal(t, 0, stats[1].Lag)
+
+			if !tt.detached {
+				assert.Equal(t, uint64(10), stats[1].Delivered+stats[1].Dropped)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package streams
+
+import "sync"
+
+// Cancelable is implemented by receivers that may stop consuming before their input is closed.
+type Cancelable interface {
+	// Done returns a channel that is closed when the receiver stops consuming.
+	Done() <-chan struct{}
+}
+
+// doneOf returns the done channel of a receiver or nil if it can not be canceled.
+func doneOf(r Receivable) <-chan struct{} {
+	if c, ok := r.(Cancelable); ok {
+		return c.Done()
+	}
+
+	return nil
+}
+
+// canceler propagates the cancelation of a downstream receiver to the upstream.
+type canceler struct {
+	done chan struct{}
+	once sync.Once
+}
+
+func newCanceler() canceler {
+	return canceler{done: make(chan struct{})}
+}
+
+// Done returns a channel that is closed when the operator stops consuming.
+func (c *canceler) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *canceler) cancel() {
+	c.once.Do(func() {
+		close(c.done)
+	})
+}
+
+// drain discards the remaining elements of a signal stream in the background,
+// so that its producer is not blocked. A nil stream is ignored.
+func drain(signal <-chan any) {
+	if signal == nil {
+		return
+	}
+
+	go func() {
+		for range signal {
+		}
+	}()
+}
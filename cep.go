@@ -0,0 +1,298 @@
+package streams
+
+import (
+	"time"
+)
+
+// Pattern is a sequence of conditions on the events of a key.
+// Patterns are built with Begin and compiled to a non-deterministic finite automaton by CEP.
+type Pattern[T any] struct {
+	stages []*patternStage[T]
+	within time.Duration
+}
+
+type patternStage[T any] struct {
+	name   string
+	preds  []FilterPredicate[T]
+	times  int
+	strict bool
+}
+
+// Begin starts a new pattern with a named stage.
+func Begin[T any](name string) *Pattern[T] {
+	p := new(Pattern[T])
+	p.stages = append(p.stages, &patternStage[T]{name: name, times: 1})
+
+	return p
+}
+
+// Where adds a condition to the current stage. All conditions of a stage must hold.
+func (p *Pattern[T]) Where(fn FilterPredicate[T]) *Pattern[T] {
+	s := p.current()
+	s.preds = append(s.preds, fn)
+
+	return p
+}
+
+// Next adds a stage that must match the event that directly follows the previous stage.
+func (p *Pattern[T]) Next(name string) *Pattern[T] {
+	p.stages = append(p.stages, &patternStage[T]{name: name, times: 1, strict: true})
+
+	return p
+}
+
+// FollowedBy adds a stage that matches any later event, non-matching events in between are skipped.
+func (p *Pattern[T]) FollowedBy(name string) *Pattern[T] {
+	p.stages = append(p.stages, &patternStage[T]{name: name, times: 1})
+
+	return p
+}
+
+// Times sets the number of events the current stage must match.
+// The repetitions follow the contiguity of the stage, they are consecutive for Next only.
+func (p *Pattern[T]) Times(n int) *Pattern[T] {
+	p.current().times = max(n, 1)
+
+	return p
+}
+
+// Within sets the maximum duration between the first and the last event of a match.
+// Partial matches that exceed the duration are discarded.
+func (p *Pattern[T]) Within(d time.Duration) *Pattern[T] {
+	p.within = d
+
+	return p
+}
+
+func (p *Pattern[T]) current() *patternStage[T] {
+	return p.stages[len(p.stages)-1]
+}
+
+// nfaState is a state of the automaton that consumes a single event.
+type nfaState[T any] struct {
+	name   string
+	preds  []FilterPredicate[T]
+	strict bool
+}
+
+func (s *nfaState[T]) matches(x T) bool {
+	for _, fn := range s.preds {
+		if !fn(x) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type nfa[T any] struct {
+	states []*nfaState[T]
+	within time.Duration
+}
+
+func (p *Pattern[T]) compile() *nfa[T] {
+	n := &nfa[T]{within: p.within}
+
+	for _, s := range p.stages {
+		for range s.times {
+			n.states = append(n.states, &nfaState[T]{name: s.name, preds: s.preds, strict: s.strict})
+		}
+	}
+
+	return n
+}
+
+// partialMatch is a run of the automaton for a key.
+type partialMatch[T any] struct {
+	state  int
+	events []T
+	start  time.Time
+}
+
+// Match is a sequence of events that matched a pattern.
+type Match[T any] struct {
+	// Key is the key of the events.
+	Key string
+	// Events are the matched events by stage name.
+	Events map[string][]T
+	// Sequence are the matched events in order.
+	Sequence []T
+}
+
+// KeyFunc returns the key of an element.
+type KeyFunc[T any] func(T) string
+
+var (
+	_ Streamable = (*CEPImpl[any])(nil)
+	_ Receivable = (*CEPImpl[any])(nil)
+)
+
+// CEPImpl detects patterns in the events of each key and emits a Match for every detected pattern.
+// After a match all other partial matches of the key are discarded.
+type CEPImpl[T any] struct {
+	nfa      *nfa[T]
+	key      KeyFunc[T]
+	partials map[string][]*partialMatch[T]
+	in       chan any
+	out      chan any
+	canceler
+}
+
+// CEP returns a new operator that detects a pattern in the events of each key.
+func CEP[T any](pattern *Pattern[T], key KeyFunc[T]) *CEPImpl[T] {
+	return NewCEP(pattern, key)
+}
+
+// NewCEP returns a new operator that detects a pattern in the events of each key.
+func NewCEP[T any](pattern *Pattern[T], key KeyFunc[T]) *CEPImpl[T] {
+	c := &CEPImpl[T]{
+		nfa:      pattern.compile(),
+		key:      key,
+		partials: make(map[string][]*partialMatch[T]),
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
+	}
+
+	go c.attach()
+
+	return c
+}
+
+// To streams data to the sink and waits for it to complete.
+func (c *CEPImpl[T]) To(sink Sinkable) error {
+	c.stream(sink)
+
+	err := sink.Wait()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// In returns the input channel.
+func (c *CEPImpl[T]) In() chan<- any {
+	return c.in
+}
+
+// Out returns the output channel.
+func (c *CEPImpl[T]) Out() <-chan any {
+	return c.out
+}
+
+// Pipe pipes the output channel to the input channel.
+func (c *CEPImpl[T]) Pipe(o Operatable) Operatable {
+	go c.stream(o)
+	return o
+}
+
+func (c *CEPImpl[T]) stream(r Receivable) {
+	done := doneOf(r)
+	for x := range c.out {
+		select {
+		case r.In() <- x:
+		case <-done:
+			c.cancel()
+		}
+	}
+
+	close(r.In())
+}
+
+func (c *CEPImpl[T]) attach() {
+	var sweep <-chan time.Time
+	if c.nfa.within > 0 {
+		ticker := time.NewTicker(c.nfa.within)
+		defer ticker.Stop()
+
+		sweep = ticker.C
+	}
+
+	for {
+		select {
+		case x, ok := <-c.in:
+			if !ok {
+				close(c.out)
+				return
+			}
+
+			if m := c.process(x.(T), time.Now()); m != nil {
+				c.out <- *m
+			}
+		case now := <-sweep:
+			for key := range c.partials {
+				c.partials[key] = c.expire(c.partials[key], now)
+				if len(c.partials[key]) == 0 {
+					delete(c.partials, key)
+				}
+			}
+		}
+	}
+}
+
+func (c *CEPImpl[T]) expire(partials []*partialMatch[T], now time.Time) []*partialMatch[T] {
+	if c.nfa.within <= 0 {
+		return partials
+	}
+
+	alive := partials[:0]
+	for _, p := range partials {
+		if now.Sub(p.start) <= c.nfa.within {
+			alive = append(alive, p)
+		}
+	}
+
+	return alive
+}
+
+func (c *CEPImpl[T]) process(x T, now time.Time) *Match[T] {
+	key := c.key(x)
+	partials := c.expire(c.partials[key], now)
+
+	next := make([]*partialMatch[T], 0, len(partials)+1)
+	for _, p := range append(partials, &partialMatch[T]{start: now}) {
+		state := c.nfa.states[p.state]
+
+		if !state.matches(x) {
+			if len(p.events) > 0 && !state.strict {
+				next = append(next, p)
+			}
+
+			continue
+		}
+
+		p.state++
+		p.events = append(p.events, x)
+
+		if p.state == len(c.nfa.states) {
+			delete(c.partials, key)
+			return c.match(key, p)
+		}
+
+		next = append(next, p)
+	}
+
+	if len(next) == 0 {
+		delete(c.partials, key)
+	} else {
+		c.partials[key] = next
+	}
+
+	return nil
+}
+
+func (c *CEPImpl[T]) match(key string, p *partialMatch[T]) *Match[T] {
+	m := &Match[T]{
+		Key:      key,
+		Events:   make(map[string][]T),
+		Sequence: p.events,
+	}
+
+	for i, x := range p.events {
+		name := c.nfa.states[i].name
+		m.Events[name] = append(m.Events[name], x)
+	}
+
+	return m
+}
@@ -0,0 +1,114 @@
+package streams_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/sinks"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type login struct {
+	user    string
+	success bool
+}
+
+func failed(l login) bool    { return !l.success }
+func succeeded(l login) bool { return l.success }
+func user(l login) string    { return l.user }
+
+func bruteForce() *streams.Pattern[login] {
+	return streams.Begin[login]("failed").Where(failed).Times(3).
+		FollowedBy("success").Where(succeeded).
+		Within(time.Minute)
+}
+
+func TestCEP(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  *streams.Pattern[login]
+		in       []login
+		expected []streams.Match[login]
+	}{
+		{
+			name:    "brute force",
+			pattern: bruteForce(),
+			in: []login{
+				{"alice", false}, {"bob", false}, {"alice", false},
+				{"bob", true}, {"alice", false}, {"alice", true},
+			},
+			expected: []streams.Match[login]{
+				{
+					Key: "alice",
+					Events: map[string][]login{
+						"failed":  {{"alice", false}, {"alice", false}, {"alice", false}},
+						"success": {{"alice", true}},
+					},
+					Sequence: []login{{"alice", false}, {"alice", false}, {"alice", false}, {"alice", true}},
+				},
+			},
+		},
+		{
+			name:    "not enough failures",
+			pattern: bruteForce(),
+			in:      []login{{"alice", false}, {"alice", false}, {"alice", true}},
+		},
+		{
+			name:    "strict contiguity",
+			pattern: streams.Begin[login]("failed").Where(failed).Next("success").Where(succeeded),
+			in: []login{
+				{"alice", false}, {"alice", false}, {"alice", true},
+				{"bob", false}, {"alice", true},
+			},
+			expected: []streams.Match[login]{
+				{
+					Key: "alice",
+					Events: map[string][]login{
+						"failed":  {{"alice", false}},
+						"success": {{"alice", true}},
+					},
+					Sequence: []login{{"alice", false}, {"alice", true}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := make(chan any, 6)
+			out := make(chan any, 6)
+
+			channels.Channel(tt.in, in)
+			close(in)
+
+			err := sources.NewChanSource(in).Pipe(streams.CEP(tt.pattern, user)).To(sinks.NewChanSink(out))
+			require.NoError(t, err)
+
+			output := channels.Slice[streams.Match[login]](out)
+			if tt.expected == nil {
+				assert.Empty(t, output)
+				return
+			}
+			assert.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func TestCEPWithin(t *testing.T) {
+	pattern := streams.Begin[login]("failed").Where(failed).FollowedBy("success").Where(succeeded).Within(10 * time.Millisecond)
+	recv := streams.CEP(pattern, user)
+
+	go func() {
+		recv.In() <- login{"alice", false}
+		time.Sleep(30 * time.Millisecond)
+		recv.In() <- login{"alice", true}
+		close(recv.In())
+	}()
+
+	output := channels.Slice[streams.Match[login]](recv.Out())
+	assert.Empty(t, output)
+}
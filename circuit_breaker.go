@@ -0,0 +1,270 @@
+package streams
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned when the circuit breaker rejects a call.
+var ErrCircuitOpen = errors.New("streams: circuit breaker is open")
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets all calls through and records their outcome.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects all calls until the open timeout elapsed.
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of trial calls through.
+	CircuitHalfOpen
+)
+
+// String returns the name of the state.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerConfig holds the configuration for a circuit breaker.
+type CircuitBreakerConfig struct {
+	// Window is the duration of the rolling window for the failure rate.
+	Window time.Duration
+	// Buckets is the number of buckets the rolling window is divided into.
+	Buckets int
+	// MinRequests is the minimum number of calls in the window before the failure rate is evaluated.
+	MinRequests int
+	// FailureRate is the failure rate between 0 and 1 at which the circuit opens.
+	FailureRate float64
+	// OpenTimeout is the time the circuit stays open before it becomes half-open.
+	OpenTimeout time.Duration
+	// HalfOpenRequests is the number of successful trial calls that close the circuit again.
+	HalfOpenRequests int
+	// OnStateChange is called on every state transition.
+	OnStateChange func(from, to CircuitState)
+}
+
+// DefaultCircuitBreakerConfig returns a default circuit breaker configuration.
+func DefaultCircuitBreakerConfig() *CircuitBreakerConfig {
+	return &CircuitBreakerConfig{
+		Window:           10 * time.Second,
+		Buckets:          10,
+		MinRequests:      10,
+		FailureRate:      0.5,
+		OpenTimeout:      5 * time.Second,
+		HalfOpenRequests: 1,
+	}
+}
+
+// CircuitBreaker protects a downstream dependency from calls while it is failing.
+type CircuitBreaker struct {
+	cfg      *CircuitBreakerConfig
+	mu       sync.Mutex
+	state    CircuitState
+	window   *rollingWindow
+	openedAt time.Time
+	trials   int
+	passed   int
+	pending  [][2]CircuitState
+}
+
+// NewCircuitBreaker returns a new circuit breaker in the closed state.
+func NewCircuitBreaker(cfg *CircuitBreakerConfig) *CircuitBreaker {
+	return &CircuitBreaker{
+		cfg:    cfg,
+		window: newRollingWindow(cfg.Window, max(cfg.Buckets, 1)),
+	}
+}
+
+// State returns the current state of the circuit breaker.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+// Protect wraps a fallible function with the circuit breaker.
+// The fallback is called instead of the function while the circuit is open.
+// If the fallback is nil, ErrCircuitOpen is returned instead.
+func Protect[T, R any](b *CircuitBreaker, fn, fallback MapErrFunc[T, R]) MapErrFunc[T, R] {
+	return func(x T) (R, error) {
+		if err := b.allow(time.Now()); err != nil {
+			if fallback != nil {
+				return fallback(x)
+			}
+
+			var r R
+			return r, err
+		}
+
+		r, err := fn(x)
+		b.record(time.Now(), err)
+
+		return r, err
+	}
+}
+
+// CircuitBreak returns a new operator that maps elements with a function protected by the circuit breaker.
+// Elements that fail are passed to the failure handler and dropped.
+func CircuitBreak[T, R any](b *CircuitBreaker, fn, fallback MapErrFunc[T, R], onFailure FailureFunc[T]) *MapErrImpl[T, R] {
+	return NewMapErr(Protect(b, fn, fallback), onFailure)
+}
+
+func (b *CircuitBreaker) allow(now time.Time) error {
+	b.mu.Lock()
+
+	if b.state == CircuitOpen && now.Sub(b.openedAt) >= b.cfg.OpenTimeout {
+		b.transition(CircuitHalfOpen)
+	}
+
+	var err error
+	switch b.state {
+	case CircuitOpen:
+		err = ErrCircuitOpen
+	case CircuitHalfOpen:
+		if b.trials >= max(b.cfg.HalfOpenRequests, 1) {
+			err = ErrCircuitOpen
+			break
+		}
+		b.trials++
+	case CircuitClosed:
+	}
+
+	b.unlock()
+
+	return err
+}
+
+func (b *CircuitBreaker) record(now time.Time, err error) {
+	b.mu.Lock()
+
+	switch b.state {
+	case CircuitClosed:
+		b.window.add(now, err != nil)
+
+		failures, total := b.window.counts(now)
+		if total >= b.cfg.MinRequests && float64(failures)/float64(total) >= b.cfg.FailureRate {
+			b.open(now)
+		}
+	case CircuitHalfOpen:
+		if err != nil {
+			b.open(now)
+			break
+		}
+
+		b.passed++
+		if b.passed >= max(b.cfg.HalfOpenRequests, 1) {
+			b.window.reset()
+			b.transition(CircuitClosed)
+		}
+	case CircuitOpen:
+		// late results of calls that started before the circuit opened are ignored
+	}
+
+	b.unlock()
+}
+
+func (b *CircuitBreaker) open(now time.Time) {
+	b.openedAt = now
+	b.transition(CircuitOpen)
+}
+
+// transition must be called with the lock held; the callback is run by unlock.
+func (b *CircuitBreaker) transition(to CircuitState) {
+	if b.state == to {
+		return
+	}
+
+	from := b.state
+	b.state = to
+	b.trials = 0
+	b.passed = 0
+
+	if b.cfg.OnStateChange != nil {
+		b.pending = append(b.pending, [2]CircuitState{from, to})
+	}
+}
+
+func (b *CircuitBreaker) unlock() {
+	pending := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	for _, t := range pending {
+		b.cfg.OnStateChange(t[0], t[1])
+	}
+}
+
+type bucket struct {
+	failures int
+	total    int
+}
+
+// rollingWindow counts outcomes in a ring of time buckets.
+type rollingWindow struct {
+	size    time.Duration
+	buckets []bucket
+	head    int
+	start   time.Time
+}
+
+func newRollingWindow(window time.Duration, n int) *rollingWindow {
+	return &rollingWindow{
+		size:    max(window/time.Duration(n), 1),
+		buckets: make([]bucket, n),
+	}
+}
+
+func (w *rollingWindow) advance(now time.Time) {
+	if w.start.IsZero() {
+		w.start = now
+		return
+	}
+
+	n := int(now.Sub(w.start) / w.size)
+	if n <= 0 {
+		return
+	}
+
+	w.start = w.start.Add(time.Duration(n) * w.size)
+	for i := 0; i < min(n, len(w.buckets)); i++ {
+		w.head = (w.head + 1) % len(w.buckets)
+		w.buckets[w.head] = bucket{}
+	}
+}
+
+func (w *rollingWindow) add(now time.Time, failed bool) {
+	w.advance(now)
+
+	w.buckets[w.head].total++
+	if failed {
+		w.buckets[w.head].failures++
+	}
+}
+
+func (w *rollingWindow) counts(now time.Time) (int, int) {
+	w.advance(now)
+
+	var failures, total int
+	for _, b := range w.buckets {
+		failures += b.failures
+		total += b.total
+	}
+
+	return failures, total
+}
+
+func (w *rollingWindow) reset() {
+	clear(w.buckets)
+	w.start = time.Time{}
+}
@@ -0,0 +1,90 @@
+package streams_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/katallaxie/streams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var mu sync.Mutex
+	transitions := make([]string, 0)
+
+	b := streams.NewCircuitBreaker(&streams.CircuitBreakerConfig{
+		Window:           time.Minute,
+		Buckets:          1,
+		MinRequests:      2,
+		FailureRate:      0.5,
+		OpenTimeout:      10 * time.Millisecond,
+		HalfOpenRequests: 1,
+		OnStateChange: func(from, to streams.CircuitState) {
+			mu.Lock()
+			defer mu.Unlock()
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+
+	failing := true
+	fn := streams.Protect(b, func(x int) (int, error) {
+		if failing {
+			return 0, errTransient
+		}
+
+		return x, nil
+	}, func(int) (int, error) {
+		return -1, nil
+	})
+
+	_, err := fn(1)
+	require.ErrorIs(t, err, errTransient)
+	assert.Equal(t, streams.CircuitClosed, b.State())
+
+	_, err = fn(1)
+	require.ErrorIs(t, err, errTransient)
+	assert.Equal(t, streams.CircuitOpen, b.State())
+
+	x, err := fn(1)
+	require.NoError(t, err)
+	assert.Equal(t, -1, x)
+
+	time.Sleep(20 * time.Millisecond)
+	failing = false
+
+	x, err = fn(1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, x)
+	assert.Equal(t, streams.CircuitClosed, b.State())
+
+	assert.Equal(t, []string{"closed->open", "open->half-open", "half-open->closed"}, transitions)
+}
+
+func TestCircuitBreakerHalfOpenFailure(t *testing.T) {
+	b := streams.NewCircuitBreaker(&streams.CircuitBreakerConfig{
+		Window:      time.Minute,
+		MinRequests: 1,
+		FailureRate: 1,
+		OpenTimeout: 10 * time.Millisecond,
+	})
+
+	fn := streams.Protect(b, func(int) (int, error) {
+		return 0, errTransient
+	}, nil)
+
+	_, err := fn(1)
+	require.ErrorIs(t, err, errTransient)
+
+	_, err = fn(1)
+	require.ErrorIs(t, err, streams.ErrCircuitOpen)
+
+	time.Sleep(20 * time.Millisecond)
+
+	_, err = fn(1)
+	require.ErrorIs(t, err, errTransient)
+	assert.Equal(t, streams.CircuitOpen, b.State())
+	assert.False(t, errors.Is(err, streams.ErrCircuitOpen))
+}
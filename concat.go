@@ -0,0 +1,73 @@
+package streams
+
+import "sync"
+
+var _ Sourceable = (*ConcatImpl)(nil)
+
+// ConcatImpl is a source that concatenates multiple streams into one.
+type ConcatImpl struct {
+	in      []Streamable
+	out     chan any
+	err     error
+	errOnce sync.Once
+}
+
+// Concat concatenates multiple streams into one.
+// Each stream is drained fully before the next stream is consumed.
+// The source stops at the first stream that fails, the remaining streams are not consumed.
+func Concat(in ...Streamable) *ConcatImpl {
+	return NewConcat(in...)
+}
+
+// NewConcat returns a new source that concatenates multiple streams into one.
+// Each stream is drained fully before the next stream is consumed.
+// The source stops at the first stream that fails, the remaining streams are not consumed.
+func NewConcat(in ...Streamable) *ConcatImpl {
+	c := &ConcatImpl{
+		in:  in,
+		out: make(chan any),
+	}
+
+	go c.attach()
+
+	return c
+}
+
+// Error returns the error.
+func (c *ConcatImpl) Error() error {
+	return c.err
+}
+
+func (c *ConcatImpl) fail(err error) {
+	c.errOnce.Do(func() {
+		c.err = err
+	})
+}
+
+// Out returns the output channel.
+func (c *ConcatImpl) Out() <-chan any {
+	return c.out
+}
+
+// Pipe pipes the output channel to the input channel.
+func (c *ConcatImpl) Pipe(o Operatable) Operatable {
+	Pipe(c, o)
+	return o
+}
+
+func (c *ConcatImpl) attach() {
+	defer close(c.out)
+
+	for _, stream := range c.in {
+		for element := range stream.Out() {
+			c.out <- element
+		}
+
+		if source, ok := stream.(Sourceable); ok {
+			if err := source.Error(); err != nil {
+				c.fail(err)
+				return
+			}
+		}
+	}
+}
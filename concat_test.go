@@ -0,0 +1,53 @@
+package streams_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/sinks"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcat(t *testing.T) {
+	snapshot, err := sources.NewSeqSource(slices.Values([]int{1, 2, 3}))
+	require.NoError(t, err)
+
+	live := make(chan any, 2)
+	channels.Channel([]int{4, 5}, live)
+	close(live)
+
+	out := make(chan any, 5)
+
+	source := streams.Concat(snapshot, sources.NewChanSource(live))
+	err = source.Pipe(streams.PassThrough()).To(sinks.NewChanSink(out))
+	require.NoError(t, err)
+	require.NoError(t, source.Error())
+
+	require.Equal(t, []int{1, 2, 3, 4, 5}, channels.Slice[int](out))
+}
+
+func TestConcatError(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	snapshot := sources.NewFuncSource(context.Background(), func(context.Context) (int, error) {
+		return 0, errFailed
+	})
+
+	live := make(chan any, 1)
+	live <- 1
+	close(live)
+
+	out := make(chan any, 1)
+
+	source := streams.Concat(snapshot, sources.NewChanSource(live))
+	err := source.Pipe(streams.PassThrough()).To(sinks.NewChanSink(out))
+	require.NoError(t, err)
+	require.ErrorIs(t, source.Error(), errFailed)
+
+	require.Empty(t, channels.Slice[int](out))
+}
@@ -0,0 +1,110 @@
+package streams
+
+var (
+	_ Streamable = (*ConflateImpl[any])(nil)
+	_ Receivable = (*ConflateImpl[any])(nil)
+)
+
+// ConflateImpl merges the pending elements into one summary while the downstream is busy,
+// so that a fast upstream never blocks on a slow downstream.
+type ConflateImpl[T any] struct {
+	fn  ReduceFunc[T]
+	in  chan any
+	out chan any
+	canceler
+}
+
+// Conflate returns a new operator that merges the pending elements while the downstream is busy.
+func Conflate[T any](fn ReduceFunc[T]) *ConflateImpl[T] {
+	return NewConflate(fn)
+}
+
+// NewConflate returns a new operator that merges the pending elements while the downstream is busy.
+func NewConflate[T any](fn ReduceFunc[T]) *ConflateImpl[T] {
+	c := &ConflateImpl[T]{
+		fn:       fn,
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
+	}
+
+	go c.attach()
+
+	return c
+}
+
+// To streams data to the sink and waits for it to complete.
+func (c *ConflateImpl[T]) To(sink Sinkable) error {
+	c.stream(sink)
+
+	err := sink.Wait()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// In returns the input channel.
+func (c *ConflateImpl[T]) In() chan<- any {
+	return c.in
+}
+
+// Out returns the output channel.
+func (c *ConflateImpl[T]) Out() <-chan any {
+	return c.out
+}
+
+// Pipe pipes the output channel to the input channel.
+func (c *ConflateImpl[T]) Pipe(o Operatable) Operatable {
+	go c.stream(o)
+	return o
+}
+
+func (c *ConflateImpl[T]) stream(r Receivable) {
+	done := doneOf(r)
+	for x := range c.out {
+		select {
+		case r.In() <- x:
+		case <-done:
+			c.cancel()
+		}
+	}
+
+	close(r.In())
+}
+
+func (c *ConflateImpl[T]) attach() {
+	var pending T
+	var has bool
+
+	in := c.in
+	for in != nil {
+		var out chan any
+		if has {
+			out = c.out
+		}
+
+		select {
+		case x, ok := <-in:
+			if !ok {
+				in = nil
+				continue
+			}
+
+			if has {
+				pending = c.fn(pending, x.(T))
+			} else {
+				pending, has = x.(T), true
+			}
+		case out <- pending:
+			has = false
+		}
+	}
+
+	if has {
+		c.out <- pending
+	}
+
+	close(c.out)
+}
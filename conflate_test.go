@@ -0,0 +1,24 @@
+package streams_test
+
+import (
+	"testing"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConflate(t *testing.T) {
+	recv := streams.Conflate(sum)
+
+	recv.In() <- 1
+	recv.In() <- 2
+	recv.In() <- 3
+
+	require.Equal(t, 6, <-recv.Out())
+
+	recv.In() <- 4
+	close(recv.In())
+
+	require.Equal(t, []int{4}, channels.Slice[int](recv.Out()))
+}
@@ -0,0 +1,77 @@
+package streams
+
+import "slices"
+
+var _ Aggregator[any, *CountMinSketch[any]] = (*CountMinSketch[any])(nil)
+
+// CountMinSketch estimates the frequency of keys in a fixed amount of memory.
+// Estimates never underestimate the true frequency.
+type CountMinSketch[T any] struct {
+	width  int
+	depth  int
+	key    KeyFunc[T]
+	counts []uint64
+}
+
+// NewCountMinSketch returns a new CountMinSketch with the given number of counters per row and rows.
+func NewCountMinSketch[T any](width, depth int, key KeyFunc[T]) *CountMinSketch[T] {
+	width, depth = max(width, 1), max(depth, 1)
+
+	return &CountMinSketch[T]{
+		width:  width,
+		depth:  depth,
+		key:    key,
+		counts: make([]uint64, width*depth),
+	}
+}
+
+// Add adds an element.
+func (c *CountMinSketch[T]) Add(x T) {
+	h := hash64(c.key(x))
+	for i := range c.depth {
+		c.counts[c.index(i, h)]++
+	}
+}
+
+// Estimate returns the estimated frequency of the key.
+func (c *CountMinSketch[T]) Estimate(key string) uint64 {
+	h := hash64(key)
+
+	var estimate uint64
+	for i := range c.depth {
+		n := c.counts[c.index(i, h)]
+		if i == 0 || n < estimate {
+			estimate = n
+		}
+	}
+
+	return estimate
+}
+
+// Result returns a copy of the sketch.
+func (c *CountMinSketch[T]) Result() *CountMinSketch[T] {
+	clone := *c
+	clone.counts = slices.Clone(c.counts)
+
+	return &clone
+}
+
+// Merge merges the state of another CountMinSketch into this one.
+func (c *CountMinSketch[T]) Merge(other *CountMinSketch[T]) error {
+	if c.width != other.width || c.depth != other.depth {
+		return ErrIncompatibleAggregate
+	}
+
+	for i, n := range other.counts {
+		c.counts[i] += n
+	}
+
+	return nil
+}
+
+// index uses double hashing to derive the counter of a row.
+func (c *CountMinSketch[T]) index(row int, h uint64) int {
+	h1, h2 := h&0xffffffff, h>>32
+
+	return row*c.width + int((h1+uint64(row)*h2)%uint64(c.width))
+}
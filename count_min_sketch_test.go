@@ -0,0 +1,44 @@
+package streams_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/katallaxie/streams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountMinSketch(t *testing.T) {
+	cms := streams.NewCountMinSketch(1000, 4, strconv.Itoa)
+
+	for i := range 1000 {
+		for range i % 10 {
+			cms.Add(i)
+		}
+	}
+
+	for i := range 1000 {
+		assert.GreaterOrEqual(t, cms.Estimate(strconv.Itoa(i)), uint64(i%10))
+	}
+
+	assert.Equal(t, uint64(5), cms.Estimate("5"))
+}
+
+func TestCountMinSketchMerge(t *testing.T) {
+	a := streams.NewCountMinSketch(100, 4, strconv.Itoa)
+	b := streams.NewCountMinSketch(100, 4, strconv.Itoa)
+
+	a.Add(1)
+	b.Add(1)
+	b.Add(2)
+
+	snapshot := a.Result()
+
+	require.NoError(t, a.Merge(b))
+	assert.Equal(t, uint64(2), a.Estimate("1"))
+	assert.Equal(t, uint64(1), a.Estimate("2"))
+	assert.Equal(t, uint64(1), snapshot.Estimate("1"))
+
+	require.ErrorIs(t, a.Merge(streams.NewCountMinSketch(10, 4, strconv.Itoa)), streams.ErrIncompatibleAggregate)
+}
@@ -0,0 +1,115 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// DeadLetter is an element that could not be processed by a stage.
+type DeadLetter struct {
+	// Element is the element that failed.
+	Element any
+	// Err is the error of the failure.
+	Err error
+	// Stage is the name of the stage that failed.
+	Stage string
+	// Attempts is the number of attempts that have been made.
+	Attempts int
+}
+
+var _ Sourceable = (*DeadLetterQueue)(nil)
+
+// DeadLetterQueue is a side output that emits the dead letters of any number of stages.
+// Stages block on sending until the dead letters are consumed or the queue is closed.
+type DeadLetterQueue struct {
+	out     chan any
+	done    chan struct{}
+	mu      sync.RWMutex
+	senders sync.WaitGroup
+	closed  bool
+}
+
+// NewDeadLetterQueue returns a new dead-letter queue.
+func NewDeadLetterQueue() *DeadLetterQueue {
+	return &DeadLetterQueue{
+		out:  make(chan any),
+		done: make(chan struct{}),
+	}
+}
+
+// Send emits a failed element to the dead-letter queue.
+// The number of attempts is taken from a RetryError and is 1 otherwise.
+func (q *DeadLetterQueue) Send(stage string, element any, err error) {
+	q.SendContext(context.Background(), stage, element, err)
+}
+
+// SendContext emits a failed element to the dead-letter queue.
+// The dead letter is dropped if the context is canceled before it is consumed.
+func (q *DeadLetterQueue) SendContext(ctx context.Context, stage string, element any, err error) {
+	attempts := 1
+
+	var retryErr *RetryError
+	if errors.As(err, &retryErr) {
+		attempts = retryErr.Attempts
+	}
+
+	q.mu.RLock()
+	if q.closed {
+		q.mu.RUnlock()
+		return
+	}
+	q.senders.Add(1)
+	q.mu.RUnlock()
+
+	defer q.senders.Done()
+
+	select {
+	case q.out <- &DeadLetter{
+		Element:  element,
+		Err:      err,
+		Stage:    stage,
+		Attempts: attempts,
+	}:
+	case <-q.done:
+	case <-ctx.Done():
+	}
+}
+
+// Close closes the dead-letter queue. Dead letters that are pending or sent afterwards are dropped.
+func (q *DeadLetterQueue) Close() {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return
+	}
+	q.closed = true
+	close(q.done)
+	q.mu.Unlock()
+
+	q.senders.Wait()
+	close(q.out)
+}
+
+// Error returns the error.
+func (q *DeadLetterQueue) Error() error {
+	return nil
+}
+
+// Out returns the output channel.
+func (q *DeadLetterQueue) Out() <-chan any {
+	return q.out
+}
+
+// Pipe pipes the output channel to the input channel.
+func (q *DeadLetterQueue) Pipe(c Operatable) Operatable {
+	Pipe(q, c)
+	return c
+}
+
+// DeadLetters returns a failure handler that sends failed elements of a stage to the dead-letter queue.
+func DeadLetters[T any](q *DeadLetterQueue, stage string) FailureFunc[T] {
+	return func(x T, err error) {
+		q.Send(stage, x, err)
+	}
+}
@@ -0,0 +1,64 @@
+package streams_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/sinks"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLetterQueue(t *testing.T) {
+	in := make(chan any, 3)
+	out := make(chan any, 3)
+	dead := make(chan any, 3)
+
+	channels.Channel([]string{"1", "a", "3"}, in)
+	close(in)
+
+	dlq := streams.NewDeadLetterQueue()
+	dlqDone := make(chan error)
+
+	go func() {
+		dlqDone <- dlq.Pipe(streams.PassThrough()).To(sinks.NewChanSink(dead))
+	}()
+
+	policy := &streams.RetryPolicy{MaxAttempts: 2}
+	recv := streams.MapErr(streams.Retry(strconv.Atoi, policy), streams.DeadLetters[string](dlq, "atoi"))
+
+	err := sources.NewChanSource(in).Pipe(recv).To(sinks.NewChanSink(out))
+	require.NoError(t, err)
+
+	dlq.Close()
+	require.NoError(t, <-dlqDone)
+
+	require.Equal(t, []int{1, 3}, channels.Slice[int](out))
+
+	letters := channels.Slice[*streams.DeadLetter](dead)
+	require.Len(t, letters, 1)
+	assert.Equal(t, "a", letters[0].Element)
+	assert.Equal(t, "atoi", letters[0].Stage)
+	assert.Equal(t, 2, letters[0].Attempts)
+	require.ErrorIs(t, letters[0].Err, strconv.ErrSyntax)
+}
+
+func TestDeadLetterQueueCloseWhileSending(t *testing.T) {
+	dlq := streams.NewDeadLetterQueue()
+
+	sent := make(chan struct{})
+	go func() {
+		dlq.Send("stage", "a", errors.New("failed"))
+		close(sent)
+	}()
+
+	dlq.Close()
+	<-sent
+
+	dlq.Send("stage", "b", errors.New("failed"))
+	require.Empty(t, channels.Slice[any](dlq.Out()))
+}
@@ -0,0 +1,149 @@
+package streams
+
+import (
+	"container/heap"
+	"time"
+)
+
+var (
+	_ Streamable = (*DelayImpl[any])(nil)
+	_ Receivable = (*DelayImpl[any])(nil)
+)
+
+// DelayImpl holds elements until their release time and emits them in time order.
+// All pending elements share a single timer, ordered by a min-heap.
+type DelayImpl[T any] struct {
+	fn  func(T) time.Time
+	in  chan any
+	out chan any
+	canceler
+}
+
+// Delay returns a new operator that delays each element by the duration.
+func Delay(d time.Duration) *DelayImpl[any] {
+	return NewDelayUntil(func(any) time.Time { return time.Now().Add(d) })
+}
+
+// DelayUntil returns a new operator that holds each element until the returned release time.
+func DelayUntil[T any](fn func(T) time.Time) *DelayImpl[T] {
+	return NewDelayUntil(fn)
+}
+
+// NewDelayUntil returns a new operator that holds each element until the returned release time.
+func NewDelayUntil[T any](fn func(T) time.Time) *DelayImpl[T] {
+	d := &DelayImpl[T]{
+		fn:       fn,
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
+	}
+
+	go d.attach()
+
+	return d
+}
+
+// To streams data to the sink and waits for it to complete.
+func (d *DelayImpl[T]) To(sink Sinkable) error {
+	d.stream(sink)
+
+	err := sink.Wait()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// In returns the input channel.
+func (d *DelayImpl[T]) In() chan<- any {
+	return d.in
+}
+
+// Out returns the output channel.
+func (d *DelayImpl[T]) Out() <-chan any {
+	return d.out
+}
+
+// Pipe pipes the output channel to the input channel.
+func (d *DelayImpl[T]) Pipe(c Operatable) Operatable {
+	go d.stream(c)
+	return c
+}
+
+func (d *DelayImpl[T]) stream(r Receivable) {
+	done := doneOf(r)
+	for x := range d.out {
+		select {
+		case r.In() <- x:
+		case <-done:
+			d.cancel()
+		}
+	}
+
+	close(r.In())
+}
+
+func (d *DelayImpl[T]) attach() {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	pending := new(delayHeap)
+	in := d.in
+
+	var seq uint64
+	for in != nil || pending.Len() > 0 {
+		var release <-chan time.Time
+		if pending.Len() > 0 {
+			timer.Reset(time.Until((*pending)[0].at))
+			release = timer.C
+		}
+
+		select {
+		case x, ok := <-in:
+			if !ok {
+				in = nil
+				continue
+			}
+
+			heap.Push(pending, delayed{at: d.fn(x.(T)), seq: seq, x: x})
+			seq++
+		case now := <-release:
+			for pending.Len() > 0 && !(*pending)[0].at.After(now) {
+				d.out <- heap.Pop(pending).(delayed).x
+			}
+		}
+	}
+
+	close(d.out)
+}
+
+type delayed struct {
+	at  time.Time
+	seq uint64
+	x   any
+}
+
+type delayHeap []delayed
+
+func (h delayHeap) Len() int { return len(h) }
+
+func (h delayHeap) Less(i, j int) bool {
+	if h[i].at.Equal(h[j].at) {
+		return h[i].seq < h[j].seq
+	}
+
+	return h[i].at.Before(h[j].at)
+}
+
+func (h delayHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *delayHeap) Push(x any) { *h = append(*h, x.(delayed)) }
+
+func (h *delayHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return x
+}
@@ -0,0 +1,47 @@
+package streams_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/sinks"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelay(t *testing.T) {
+	in := make(chan any, 3)
+	out := make(chan any, 3)
+
+	channels.Channel([]int{1, 2, 3}, in)
+	close(in)
+
+	start := time.Now()
+
+	err := sources.NewChanSource(in).Pipe(streams.Delay(20 * time.Millisecond)).To(sinks.NewChanSink(out))
+	require.NoError(t, err)
+
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	require.Equal(t, []int{1, 2, 3}, channels.Slice[int](out))
+}
+
+func TestDelayUntil(t *testing.T) {
+	in := make(chan any, 4)
+	out := make(chan any, 4)
+
+	start := time.Now()
+	channels.Channel([]time.Duration{30, 10, 20, 0}, in)
+	close(in)
+
+	recv := streams.DelayUntil(func(d time.Duration) time.Time {
+		return start.Add(d * time.Millisecond)
+	})
+
+	err := sources.NewChanSource(in).Pipe(recv).To(sinks.NewChanSink(out))
+	require.NoError(t, err)
+
+	require.Equal(t, []time.Duration{0, 10, 20, 30}, channels.Slice[time.Duration](out))
+}
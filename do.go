@@ -10,9 +10,11 @@ var (
 
 // DoImpl takes one element and executes a function on it.
 type DoImpl[T any] struct {
-	fn  DoFunc[T]
-	in  chan any
-	out chan any
+	fn        DoFunc[T]
+	onFailure FailureFunc[T]
+	in        chan any
+	out       chan any
+	canceler
 }
 
 // Do returns a new Do.
@@ -22,10 +24,23 @@ func Do[T any](fn DoFunc[T]) *DoImpl[T] {
 
 // NewDo creates a new Do.
 func NewDo[T any](fn DoFunc[T]) *DoImpl[T] {
+	return NewDoWithFailure(fn, nil)
+}
+
+// DoWithFailure returns a new Do that passes failed elements to the failure handler
+// and drops them instead of stopping the stream.
+func DoWithFailure[T any](fn DoFunc[T], onFailure FailureFunc[T]) *DoImpl[T] {
+	return NewDoWithFailure(fn, onFailure)
+}
+
+// NewDoWithFailure creates a new Do that passes failed elements to the failure handler.
+func NewDoWithFailure[T any](fn DoFunc[T], onFailure FailureFunc[T]) *DoImpl[T] {
 	t := &DoImpl[T]{
-		fn:  fn,
-		in:  make(chan any),
-		out: make(chan any),
+		fn:        fn,
+		onFailure: onFailure,
+		in:        make(chan any),
+		out:       make(chan any),
+		canceler:  newCanceler(),
 	}
 
 	go t.attach()
@@ -62,8 +77,13 @@ func (d *DoImpl[T]) Pipe(c Operatable) Operatable {
 }
 
 func (d *DoImpl[T]) stream(r Receivable) {
+	done := doneOf(r)
 	for x := range d.out {
-		r.In() <- x
+		select {
+		case r.In() <- x:
+		case <-done:
+			d.cancel()
+		}
 	}
 
 	close(r.In())
@@ -72,6 +92,11 @@ func (d *DoImpl[T]) stream(r Receivable) {
 func (d *DoImpl[T]) attach() {
 	for x := range d.in {
 		err := d.fn(x.(T))
+		if err != nil && d.onFailure != nil {
+			d.onFailure(x.(T), err)
+			continue
+		}
+
 		if err != nil {
 			break
 		}
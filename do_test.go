@@ -1,6 +1,7 @@
 package streams_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/katallaxie/pkg/channels"
@@ -45,3 +46,30 @@ func TestDo(t *testing.T) {
 		})
 	}
 }
+
+func TestDoWithFailure(t *testing.T) {
+	in := make(chan any, 3)
+	out := make(chan any, 3)
+
+	channels.Channel([]string{"a", "b", "c"}, in)
+
+	failed := make([]string, 0)
+	recv := streams.DoWithFailure(func(x string) error {
+		if x == "b" {
+			return errors.New("failed")
+		}
+
+		return nil
+	}, func(x string, _ error) { failed = append(failed, x) })
+
+	source := sources.NewChanSource(in)
+	sink := sinks.NewChanSink(out)
+
+	close(in)
+
+	err := source.Pipe(recv).To(sink)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"a", "c"}, channels.Slice[string](out))
+	require.Equal(t, []string{"b"}, failed)
+}
@@ -1,6 +1,9 @@
 package main
 
 import (
+	"context"
+	"os"
+	"os/signal"
 	"strconv"
 	"time"
 
@@ -18,7 +21,13 @@ func (msg *message) String() string {
 }
 
 func main() {
-	err := sources.NewChanSource(tickerChan(time.Second)).
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	err := sources.Ticker(ctx, time.Second).
+		Pipe(streams.Map(func(t time.Time) *message {
+			return &message{msg: strconv.FormatInt(t.UnixMilli(), 10)}
+		})).
 		Pipe(streams.PassThrough()).
 		Pipe(streams.Timeout(5 * time.Second)).
 		To(sinks.DefaultStdout)
@@ -26,16 +35,3 @@ func main() {
 		panic(err)
 	}
 }
-
-func tickerChan(interval time.Duration) chan any {
-	outChan := make(chan any)
-
-	go func() {
-		ticker := time.NewTicker(interval)
-		for t := range ticker.C {
-			outChan <- &message{msg: strconv.FormatInt(t.UnixMilli(), 10)}
-		}
-	}()
-
-	return outChan
-}
@@ -0,0 +1,119 @@
+package streams
+
+import "iter"
+
+// ExpandFunc returns the values to emit for an element until the next element arrives.
+type ExpandFunc[T, R any] func(T) iter.Seq[R]
+
+var (
+	_ Streamable = (*ExpandImpl[any, any])(nil)
+	_ Receivable = (*ExpandImpl[any, any])(nil)
+)
+
+// ExpandImpl emits the values of the last element while the downstream is faster than the upstream.
+// When a new element arrives, the remaining values of the last element are discarded.
+type ExpandImpl[T, R any] struct {
+	fn  ExpandFunc[T, R]
+	in  chan any
+	out chan any
+	canceler
+}
+
+// Expand returns a new operator that repeats or extrapolates the last element while the downstream is faster.
+// A function that repeats the element forever keeps the downstream supplied with the latest element.
+func Expand[T, R any](fn ExpandFunc[T, R]) *ExpandImpl[T, R] {
+	return NewExpand(fn)
+}
+
+// NewExpand returns a new operator that repeats or extrapolates the last element while the downstream is faster.
+func NewExpand[T, R any](fn ExpandFunc[T, R]) *ExpandImpl[T, R] {
+	e := &ExpandImpl[T, R]{
+		fn:       fn,
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
+	}
+
+	go e.attach()
+
+	return e
+}
+
+// To streams data to the sink and waits for it to complete.
+func (e *ExpandImpl[T, R]) To(sink Sinkable) error {
+	e.stream(sink)
+
+	err := sink.Wait()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// In returns the input channel.
+func (e *ExpandImpl[T, R]) In() chan<- any {
+	return e.in
+}
+
+// Out returns the output channel.
+func (e *ExpandImpl[T, R]) Out() <-chan any {
+	return e.out
+}
+
+// Pipe pipes the output channel to the input channel.
+func (e *ExpandImpl[T, R]) Pipe(c Operatable) Operatable {
+	go e.stream(c)
+	return c
+}
+
+func (e *ExpandImpl[T, R]) stream(r Receivable) {
+	done := doneOf(r)
+	for x := range e.out {
+		select {
+		case r.In() <- x:
+		case <-done:
+			e.cancel()
+		}
+	}
+
+	close(r.In())
+}
+
+func (e *ExpandImpl[T, R]) attach() {
+	next, stop := func() (R, bool) { var r R; return r, false }, func() {}
+	defer func() { stop() }()
+
+	var pending R
+	var has bool
+
+	for {
+		if !has {
+			pending, has = next()
+		}
+
+		var out chan any
+		if has {
+			out = e.out
+		}
+
+		select {
+		case x, ok := <-e.in:
+			if !ok {
+				if has {
+					e.out <- pending
+				}
+
+				close(e.out)
+
+				return
+			}
+
+			stop()
+			next, stop = iter.Pull(e.fn(x.(T)))
+			has = false
+		case out <- pending:
+			has = false
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package streams_test
+
+import (
+	"iter"
+	"testing"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/stretchr/testify/require"
+)
+
+func repeat(x int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for yield(x) {
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	recv := streams.Expand(repeat)
+
+	recv.In() <- 1
+	require.Equal(t, 1, <-recv.Out())
+	require.Equal(t, 1, <-recv.Out())
+	require.Equal(t, 1, <-recv.Out())
+
+	recv.In() <- 2
+	require.Equal(t, 2, <-recv.Out())
+	close(recv.In())
+
+	output := channels.Slice[int](recv.Out())
+	require.NotEmpty(t, output)
+
+	for _, x := range output {
+		require.Equal(t, 2, x)
+	}
+}
+
+func TestExpandFinite(t *testing.T) {
+	recv := streams.Expand(func(x int) iter.Seq[int] {
+		return func(yield func(int) bool) {
+			_ = yield(x) && yield(x+1)
+		}
+	})
+
+	recv.In() <- 1
+	require.Equal(t, 1, <-recv.Out())
+	require.Equal(t, 2, <-recv.Out())
+
+	recv.In() <- 10
+	close(recv.In())
+
+	require.Equal(t, 10, <-recv.Out())
+}
@@ -13,14 +13,16 @@ type Filter[T any] struct {
 	fn  FilterPredicate[T]
 	in  chan any
 	out chan any
+	canceler
 }
 
 // NewFilter returns a new operator on filters.
 func NewFilter[T any](fn FilterPredicate[T]) *Filter[T] {
 	t := &Filter[T]{
-		fn:  fn,
-		in:  make(chan any),
-		out: make(chan any),
+		fn:       fn,
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
 	}
 
 	go t.attach()
@@ -57,8 +59,13 @@ func (f *Filter[T]) Pipe(c Operatable) Operatable {
 }
 
 func (f *Filter[T]) stream(recv Receivable) {
+	done := doneOf(recv)
 	for x := range f.out {
-		recv.In() <- x
+		select {
+		case recv.In() <- x:
+		case <-done:
+			f.cancel()
+		}
 	}
 
 	close(recv.In())
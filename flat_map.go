@@ -13,14 +13,16 @@ type FlatMap[T, R any] struct {
 	fn  FlatMapFunc[T, R]
 	in  chan any
 	out chan any
+	canceler
 }
 
 // NewFlatMap returns a new operator on maps.
 func NewFlatMap[T, R any](fn FlatMapFunc[T, R]) *FlatMap[T, R] {
 	t := &FlatMap[T, R]{
-		fn:  fn,
-		in:  make(chan any),
-		out: make(chan any),
+		fn:       fn,
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
 	}
 
 	go t.attach()
@@ -57,8 +59,13 @@ func (f *FlatMap[T, R]) Pipe(c Operatable) Operatable {
 }
 
 func (f *FlatMap[T, R]) stream(r Receivable) {
+	done := doneOf(r)
 	for x := range f.out {
-		r.In() <- x
+		select {
+		case r.In() <- x:
+		case <-done:
+			f.cancel()
+		}
 	}
 
 	close(r.In())
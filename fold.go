@@ -0,0 +1,86 @@
+package streams
+
+var (
+	_ Streamable = (*FoldImpl[any, any])(nil)
+	_ Receivable = (*FoldImpl[any, any])(nil)
+)
+
+// FoldImpl folds the elements into an accumulator starting from a seed and emits the final accumulator.
+type FoldImpl[T, A any] struct {
+	seed A
+	fn   ScanFunc[T, A]
+	in   chan any
+	out  chan any
+	canceler
+}
+
+// Fold returns a new operator that emits the final accumulator when the upstream closes.
+func Fold[T, A any](seed A, fn ScanFunc[T, A]) *FoldImpl[T, A] {
+	return NewFold(seed, fn)
+}
+
+// NewFold returns a new operator that emits the final accumulator when the upstream closes.
+func NewFold[T, A any](seed A, fn ScanFunc[T, A]) *FoldImpl[T, A] {
+	f := &FoldImpl[T, A]{
+		seed:     seed,
+		fn:       fn,
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
+	}
+
+	go f.attach()
+
+	return f
+}
+
+// To streams data to the sink and waits for it to complete.
+func (f *FoldImpl[T, A]) To(sink Sinkable) error {
+	f.stream(sink)
+
+	err := sink.Wait()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// In returns the input channel.
+func (f *FoldImpl[T, A]) In() chan<- any {
+	return f.in
+}
+
+// Out returns the output channel.
+func (f *FoldImpl[T, A]) Out() <-chan any {
+	return f.out
+}
+
+// Pipe pipes the output channel to the input channel.
+func (f *FoldImpl[T, A]) Pipe(c Operatable) Operatable {
+	go f.stream(c)
+	return c
+}
+
+func (f *FoldImpl[T, A]) stream(r Receivable) {
+	done := doneOf(r)
+	for x := range f.out {
+		select {
+		case r.In() <- x:
+		case <-done:
+			f.cancel()
+		}
+	}
+
+	close(r.In())
+}
+
+func (f *FoldImpl[T, A]) attach() {
+	acc := f.seed
+	for x := range f.in {
+		acc = f.fn(acc, x.(T))
+	}
+
+	f.out <- acc
+	close(f.out)
+}
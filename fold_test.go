@@ -0,0 +1,53 @@
+package streams_test
+
+import (
+	"testing"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/sinks"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		name     string
+		recv     streams.Operatable
+		in       []string
+		expected []map[string]int
+	}{
+		{
+			name:     "count",
+			in:       []string{"a", "b", "a"},
+			expected: []map[string]int{{"a": 2, "b": 1}},
+			recv:     streams.Fold(map[string]int{}, count),
+		},
+		{
+			name:     "empty",
+			in:       []string{},
+			expected: []map[string]int{{}},
+			recv:     streams.Fold(map[string]int{}, count),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := make(chan any, 3)
+			out := make(chan any, 3)
+
+			channels.Channel(tt.in, in)
+
+			source := sources.NewChanSource(in)
+			sink := sinks.NewChanSink(out)
+
+			close(in)
+
+			err := source.Pipe(tt.recv).To(sink)
+			require.NoError(t, err)
+
+			output := channels.Slice[map[string]int](out)
+			require.Equal(t, tt.expected, output)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package streams
+
+import (
+	"time"
+)
+
+var (
+	_ Streamable = (*HeartbeatImpl)(nil)
+	_ Receivable = (*HeartbeatImpl)(nil)
+)
+
+// HeartbeatImpl injects a keep-alive value whenever no element arrives for a duration.
+type HeartbeatImpl struct {
+	dur   time.Duration
+	value any
+	in    chan any
+	out   chan any
+	canceler
+}
+
+// Heartbeat returns a new operator that emits the value whenever no element arrives for the duration.
+func Heartbeat(dur time.Duration, value any) *HeartbeatImpl {
+	return NewHeartbeat(dur, value)
+}
+
+// NewHeartbeat returns a new operator that emits the value whenever no element arrives for the duration.
+func NewHeartbeat(dur time.Duration, value any) *HeartbeatImpl {
+	h := &HeartbeatImpl{
+		dur:      dur,
+		value:    value,
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
+	}
+
+	go h.attach()
+
+	return h
+}
+
+// To streams data to the sink and waits for it to complete.
+func (h *HeartbeatImpl) To(sink Sinkable) error {
+	h.stream(sink)
+
+	err := sink.Wait()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// In returns the input channel.
+func (h *HeartbeatImpl) In() chan<- any {
+	return h.in
+}
+
+// Out returns the output channel.
+func (h *HeartbeatImpl) Out() <-chan any {
+	return h.out
+}
+
+// Pipe pipes the output channel to the input channel.
+func (h *HeartbeatImpl) Pipe(c Operatable) Operatable {
+	go h.stream(c)
+	return c
+}
+
+func (h *HeartbeatImpl) stream(r Receivable) {
+	done := doneOf(r)
+	for x := range h.out {
+		select {
+		case r.In() <- x:
+		case <-done:
+			h.cancel()
+		}
+	}
+
+	close(r.In())
+}
+
+func (h *HeartbeatImpl) attach() {
+	timer := time.NewTimer(h.dur)
+	defer timer.Stop()
+
+loop:
+	for {
+		select {
+		case x, ok := <-h.in:
+			if !ok {
+				break loop
+			}
+
+			h.out <- x
+		case <-timer.C:
+			h.out <- h.value
+		}
+
+		timer.Reset(h.dur)
+	}
+
+	close(h.out)
+}
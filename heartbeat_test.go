@@ -0,0 +1,30 @@
+package streams_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeartbeat(t *testing.T) {
+	recv := streams.Heartbeat(10*time.Millisecond, 0)
+
+	go func() {
+		recv.In() <- 1
+		time.Sleep(25 * time.Millisecond)
+		recv.In() <- 2
+		close(recv.In())
+	}()
+
+	output := channels.Slice[int](recv.Out())
+	require.GreaterOrEqual(t, len(output), 3)
+	require.Equal(t, 1, output[0])
+	require.Equal(t, 2, output[len(output)-1])
+
+	for _, x := range output[1 : len(output)-1] {
+		require.Equal(t, 0, x)
+	}
+}
@@ -0,0 +1,34 @@
+package streams_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+)
+
+// collect reads all outputs concurrently and returns their elements by name.
+func collect(t *testing.T, outputs map[string]streams.Operatable) map[string][]any {
+	t.Helper()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	res := make(map[string][]any, len(outputs))
+	for name, flow := range outputs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			elements := channels.Slice[any](flow.Out())
+
+			mu.Lock()
+			defer mu.Unlock()
+			res[name] = elements
+		}()
+	}
+	wg.Wait()
+
+	return res
+}
@@ -0,0 +1,86 @@
+package streams
+
+import (
+	"math"
+	"math/bits"
+)
+
+var _ Aggregator[any, uint64] = (*ApproxDistinct[any])(nil)
+
+// ApproxDistinct estimates the number of distinct keys with HyperLogLog.
+// It uses 2^precision bytes of memory with a standard error of about 1.04/sqrt(2^precision).
+type ApproxDistinct[T any] struct {
+	precision uint8
+	key       KeyFunc[T]
+	registers []uint8
+}
+
+// NewApproxDistinct returns a new ApproxDistinct with a precision between 4 and 16.
+func NewApproxDistinct[T any](precision uint8, key KeyFunc[T]) *ApproxDistinct[T] {
+	precision = min(max(precision, 4), 16)
+
+	return &ApproxDistinct[T]{
+		precision: precision,
+		key:       key,
+		registers: make([]uint8, 1<<precision),
+	}
+}
+
+// Add adds an element.
+func (a *ApproxDistinct[T]) Add(x T) {
+	h := hash64(a.key(x))
+
+	idx := h >> (64 - a.precision)
+	rank := uint8(bits.LeadingZeros64(h<<a.precision|1<<(a.precision-1))) + 1
+
+	a.registers[idx] = max(a.registers[idx], rank)
+}
+
+// Result returns the estimated number of distinct keys.
+func (a *ApproxDistinct[T]) Result() uint64 {
+	m := float64(len(a.registers))
+
+	var sum float64
+	var zeros int
+	for _, r := range a.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	estimate := hllAlpha(m) * m * m / sum
+
+	// linear counting is more accurate for small cardinalities
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+
+	return uint64(estimate + 0.5)
+}
+
+// Merge merges the state of another ApproxDistinct into this one.
+func (a *ApproxDistinct[T]) Merge(other *ApproxDistinct[T]) error {
+	if a.precision != other.precision {
+		return ErrIncompatibleAggregate
+	}
+
+	for i, r := range other.registers {
+		a.registers[i] = max(a.registers[i], r)
+	}
+
+	return nil
+}
+
+func hllAlpha(m float64) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/m)
+	}
+}
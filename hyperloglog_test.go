@@ -0,0 +1,47 @@
+package streams_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/katallaxie/streams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApproxDistinct(t *testing.T) {
+	tests := []struct {
+		name     string
+		distinct int
+	}{
+		{name: "small", distinct: 100},
+		{name: "large", distinct: 100000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hll := streams.NewApproxDistinct(14, strconv.Itoa)
+
+			for i := range 2 * tt.distinct {
+				hll.Add(i % tt.distinct)
+			}
+
+			assert.InEpsilon(t, tt.distinct, hll.Result(), 0.03)
+		})
+	}
+}
+
+func TestApproxDistinctMerge(t *testing.T) {
+	a := streams.NewApproxDistinct(14, strconv.Itoa)
+	b := streams.NewApproxDistinct(14, strconv.Itoa)
+
+	for i := range 10000 {
+		a.Add(i)
+		b.Add(i + 5000)
+	}
+
+	require.NoError(t, a.Merge(b))
+	assert.InEpsilon(t, 15000, a.Result(), 0.03)
+
+	require.ErrorIs(t, a.Merge(streams.NewApproxDistinct(10, strconv.Itoa)), streams.ErrIncompatibleAggregate)
+}
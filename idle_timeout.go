@@ -0,0 +1,125 @@
+package streams
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrIdleTimeout is the error of an IdleTimeout operator when no element arrived in time.
+var ErrIdleTimeout = errors.New("streams: no element arrived within the idle timeout")
+
+var (
+	_ Streamable = (*IdleTimeoutImpl)(nil)
+	_ Receivable = (*IdleTimeoutImpl)(nil)
+	_ Cancelable = (*IdleTimeoutImpl)(nil)
+	_ Sourceable = (*IdleTimeoutImpl)(nil)
+)
+
+// IdleTimeoutImpl completes the stream and cancels the upstream when no element arrives for a duration.
+// The timeout is reported by Error and by To. The operators downstream see a completed stream,
+// so Error has to be checked after the pipeline completed if the operator is not the last one.
+type IdleTimeoutImpl struct {
+	dur     time.Duration
+	in      chan any
+	out     chan any
+	err     error
+	errOnce sync.Once
+	canceler
+}
+
+// IdleTimeout returns a new operator that completes the stream when no element arrives for the duration.
+func IdleTimeout(dur time.Duration) *IdleTimeoutImpl {
+	return NewIdleTimeout(dur)
+}
+
+// NewIdleTimeout returns a new operator that completes the stream when no element arrives for the duration.
+func NewIdleTimeout(dur time.Duration) *IdleTimeoutImpl {
+	t := &IdleTimeoutImpl{
+		dur:      dur,
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
+	}
+
+	go t.attach()
+
+	return t
+}
+
+// Error returns ErrIdleTimeout if the stream has been completed by the timeout.
+func (t *IdleTimeoutImpl) Error() error {
+	return t.err
+}
+
+func (t *IdleTimeoutImpl) fail(err error) {
+	t.errOnce.Do(func() {
+		t.err = err
+	})
+}
+
+// To streams data to the sink and waits for it to complete.
+func (t *IdleTimeoutImpl) To(sink Sinkable) error {
+	t.stream(sink)
+
+	err := sink.Wait()
+	if err != nil {
+		return err
+	}
+
+	return t.Error()
+}
+
+// In returns the input channel.
+func (t *IdleTimeoutImpl) In() chan<- any {
+	return t.in
+}
+
+// Out returns the output channel.
+func (t *IdleTimeoutImpl) Out() <-chan any {
+	return t.out
+}
+
+// Pipe pipes the output channel to the input channel.
+func (t *IdleTimeoutImpl) Pipe(c Operatable) Operatable {
+	go t.stream(c)
+	return c
+}
+
+func (t *IdleTimeoutImpl) stream(r Receivable) {
+	done := doneOf(r)
+	for x := range t.out {
+		select {
+		case r.In() <- x:
+		case <-done:
+			t.cancel()
+		}
+	}
+
+	close(r.In())
+}
+
+func (t *IdleTimeoutImpl) attach() {
+	timer := time.NewTimer(t.dur)
+	defer timer.Stop()
+
+loop:
+	for {
+		select {
+		case x, ok := <-t.in:
+			if !ok {
+				break loop
+			}
+
+			t.out <- x
+			timer.Reset(t.dur)
+		case <-timer.C:
+			t.fail(ErrIdleTimeout)
+			t.cancel()
+
+			break loop
+		}
+	}
+
+	close(t.out)
+}
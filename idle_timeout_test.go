@@ -0,0 +1,63 @@
+package streams_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/sinks"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdleTimeout(t *testing.T) {
+	in := make(chan any)
+	out := make(chan any, 3)
+
+	go func() {
+		in <- 1
+		in <- 2
+		// stall without closing the input
+	}()
+
+	recv := streams.IdleTimeout(20 * time.Millisecond)
+
+	err := sources.NewChanSource(in).Pipe(recv).To(sinks.NewChanSink(out))
+	require.ErrorIs(t, err, streams.ErrIdleTimeout)
+	require.ErrorIs(t, recv.Error(), streams.ErrIdleTimeout)
+
+	require.Equal(t, []int{1, 2}, channels.Slice[int](out))
+}
+
+func TestIdleTimeoutComplete(t *testing.T) {
+	in := make(chan any, 2)
+	out := make(chan any, 2)
+
+	channels.Channel([]int{1, 2}, in)
+	close(in)
+
+	err := sources.NewChanSource(in).Pipe(streams.IdleTimeout(time.Second)).To(sinks.NewChanSink(out))
+	require.NoError(t, err)
+
+	require.Equal(t, []int{1, 2}, channels.Slice[int](out))
+}
+
+func TestIdleTimeoutMidPipeline(t *testing.T) {
+	in := make(chan any)
+	out := make(chan any, 1)
+
+	go func() {
+		in <- 1
+		// stall without closing the input
+	}()
+
+	recv := streams.IdleTimeout(20 * time.Millisecond)
+
+	// the downstream sees a completed stream, the timeout is reported by Error
+	err := sources.NewChanSource(in).Pipe(recv).Pipe(streams.PassThrough()).To(sinks.NewChanSink(out))
+	require.NoError(t, err)
+	require.ErrorIs(t, recv.Error(), streams.ErrIdleTimeout)
+
+	require.Equal(t, []int{1}, channels.Slice[int](out))
+}
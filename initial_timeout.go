@@ -0,0 +1,125 @@
+package streams
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrInitialTimeout is the error of an InitialTimeout operator when the first element did not arrive in time.
+var ErrInitialTimeout = errors.New("streams: first element did not arrive within the initial timeout")
+
+var (
+	_ Streamable = (*InitialTimeoutImpl)(nil)
+	_ Receivable = (*InitialTimeoutImpl)(nil)
+	_ Cancelable = (*InitialTimeoutImpl)(nil)
+	_ Sourceable = (*InitialTimeoutImpl)(nil)
+)
+
+// InitialTimeoutImpl fails the stream and cancels the upstream when the first element does not arrive in time.
+// The timeout is reported by Error and by To. The operators downstream see a completed stream,
+// so Error has to be checked after the pipeline completed if the operator is not the last one.
+type InitialTimeoutImpl struct {
+	dur     time.Duration
+	in      chan any
+	out     chan any
+	err     error
+	errOnce sync.Once
+	canceler
+}
+
+// InitialTimeout returns a new operator that fails the stream when the first element does not arrive within the duration.
+func InitialTimeout(dur time.Duration) *InitialTimeoutImpl {
+	return NewInitialTimeout(dur)
+}
+
+// NewInitialTimeout returns a new operator that fails the stream when the first element does not arrive within the duration.
+func NewInitialTimeout(dur time.Duration) *InitialTimeoutImpl {
+	t := &InitialTimeoutImpl{
+		dur:      dur,
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
+	}
+
+	go t.attach()
+
+	return t
+}
+
+// Error returns ErrInitialTimeout if the first element did not arrive in time.
+func (t *InitialTimeoutImpl) Error() error {
+	return t.err
+}
+
+func (t *InitialTimeoutImpl) fail(err error) {
+	t.errOnce.Do(func() {
+		t.err = err
+	})
+}
+
+// To streams data to the sink and waits for it to complete.
+func (t *InitialTimeoutImpl) To(sink Sinkable) error {
+	t.stream(sink)
+
+	err := sink.Wait()
+	if err != nil {
+		return err
+	}
+
+	return t.Error()
+}
+
+// In returns the input channel.
+func (t *InitialTimeoutImpl) In() chan<- any {
+	return t.in
+}
+
+// Out returns the output channel.
+func (t *InitialTimeoutImpl) Out() <-chan any {
+	return t.out
+}
+
+// Pipe pipes the output channel to the input channel.
+func (t *InitialTimeoutImpl) Pipe(c Operatable) Operatable {
+	go t.stream(c)
+	return c
+}
+
+func (t *InitialTimeoutImpl) stream(r Receivable) {
+	done := doneOf(r)
+	for x := range t.out {
+		select {
+		case r.In() <- x:
+		case <-done:
+			t.cancel()
+		}
+	}
+
+	close(r.In())
+}
+
+func (t *InitialTimeoutImpl) attach() {
+	defer close(t.out)
+
+	timer := time.NewTimer(t.dur)
+	defer timer.Stop()
+
+	select {
+	case x, ok := <-t.in:
+		if !ok {
+			return
+		}
+
+		t.out <- x
+	case <-timer.C:
+		t.fail(ErrInitialTimeout)
+		t.cancel()
+
+		return
+	}
+
+	for x := range t.in {
+		t.out <- x
+	}
+}
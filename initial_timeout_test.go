@@ -0,0 +1,50 @@
+package streams_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/sinks"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitialTimeout(t *testing.T) {
+	tests := []struct {
+		name     string
+		delay    time.Duration
+		expected []int
+		err      error
+	}{
+		{
+			name:     "in time",
+			expected: []int{1, 2},
+		},
+		{
+			name:     "too late",
+			delay:    50 * time.Millisecond,
+			expected: []int{},
+			err:      streams.ErrInitialTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := make(chan any)
+			out := make(chan any, 2)
+
+			go func() {
+				time.Sleep(tt.delay)
+				channels.Channel([]int{1, 2}, in)
+				close(in)
+			}()
+
+			err := sources.NewChanSource(in).Pipe(streams.InitialTimeout(10 * time.Millisecond)).To(sinks.NewChanSink(out))
+			require.ErrorIs(t, err, tt.err)
+
+			require.Equal(t, tt.expected, channels.Slice[int](out))
+		})
+	}
+}
@@ -14,6 +14,7 @@ type LogImpl struct {
 	fn  logx.LogFunc
 	in  chan any
 	out chan any
+	canceler
 }
 
 // Log returns a new operator to log elements.
@@ -24,9 +25,10 @@ func Log(fn logx.LogFunc) *LogImpl {
 // NewLog returns a new operator to log elements.
 func NewLog(fn logx.LogFunc) *LogImpl {
 	l := &LogImpl{
-		fn:  fn,
-		in:  make(chan any),
-		out: make(chan any),
+		fn:       fn,
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
 	}
 
 	go l.attach()
@@ -63,10 +65,15 @@ func (l *LogImpl) Pipe(c Operatable) Operatable {
 }
 
 func (l *LogImpl) stream(r Receivable) {
+	done := doneOf(r)
 	go func() {
 		for x := range l.in {
 			l.fn.Printf("%v", x)
-			r.In() <- x
+			select {
+			case r.In() <- x:
+			case <-done:
+				l.cancel()
+			}
 		}
 
 		close(l.out)
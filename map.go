@@ -13,6 +13,7 @@ type MapImpl[T, R any] struct {
 	fn  MapFunc[T, R]
 	in  chan any
 	out chan any
+	canceler
 }
 
 // Map returns a new operator on maps.
@@ -23,9 +24,10 @@ func Map[T, R any](fn MapFunc[T, R]) *MapImpl[T, R] {
 // NewMap returns a new operator on maps.
 func NewMap[T, R any](fn MapFunc[T, R]) *MapImpl[T, R] {
 	t := &MapImpl[T, R]{
-		fn:  fn,
-		in:  make(chan any),
-		out: make(chan any),
+		fn:       fn,
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
 	}
 
 	go t.attach()
@@ -62,8 +64,13 @@ func (m *MapImpl[T, R]) Pipe(c Operatable) Operatable {
 }
 
 func (m *MapImpl[T, R]) stream(r Receivable) {
+	done := doneOf(r)
 	for x := range m.out {
-		r.In() <- x
+		select {
+		case r.In() <- x:
+		case <-done:
+			m.cancel()
+		}
 	}
 
 	close(r.In())
@@ -0,0 +1,100 @@
+package streams
+
+// MapErrFunc is a fallible function that takes an element and returns a new element.
+type MapErrFunc[T, R any] func(T) (R, error)
+
+// FailureFunc handles an element that could not be processed.
+type FailureFunc[T any] func(T, error)
+
+var (
+	_ Streamable = (*MapErrImpl[any, any])(nil)
+	_ Receivable = (*MapErrImpl[any, any])(nil)
+)
+
+// MapErrImpl takes one element and produces a new element or routes the element to a failure handler.
+type MapErrImpl[T, R any] struct {
+	fn        MapErrFunc[T, R]
+	onFailure FailureFunc[T]
+	in        chan any
+	out       chan any
+	canceler
+}
+
+// MapErr returns a new operator on fallible maps.
+// Elements that fail are passed to the failure handler and dropped.
+func MapErr[T, R any](fn MapErrFunc[T, R], onFailure FailureFunc[T]) *MapErrImpl[T, R] {
+	return NewMapErr(fn, onFailure)
+}
+
+// NewMapErr returns a new operator on fallible maps.
+func NewMapErr[T, R any](fn MapErrFunc[T, R], onFailure FailureFunc[T]) *MapErrImpl[T, R] {
+	m := &MapErrImpl[T, R]{
+		fn:        fn,
+		onFailure: onFailure,
+		in:        make(chan any),
+		out:       make(chan any),
+		canceler:  newCanceler(),
+	}
+
+	go m.attach()
+
+	return m
+}
+
+// To streams data to the sink and waits for it to complete.
+func (m *MapErrImpl[T, R]) To(sink Sinkable) error {
+	m.stream(sink)
+
+	err := sink.Wait()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// In returns the input channel.
+func (m *MapErrImpl[T, R]) In() chan<- any {
+	return m.in
+}
+
+// Out returns the output channel.
+func (m *MapErrImpl[T, R]) Out() <-chan any {
+	return m.out
+}
+
+// Pipe pipes the output channel to the input channel.
+func (m *MapErrImpl[T, R]) Pipe(c Operatable) Operatable {
+	go m.stream(c)
+	return c
+}
+
+func (m *MapErrImpl[T, R]) stream(r Receivable) {
+	done := doneOf(r)
+	for x := range m.out {
+		select {
+		case r.In() <- x:
+		case <-done:
+			m.cancel()
+		}
+	}
+
+	close(r.In())
+}
+
+func (m *MapErrImpl[T, R]) attach() {
+	for x := range m.in {
+		y, err := m.fn(x.(T))
+		if err != nil {
+			if m.onFailure != nil {
+				m.onFailure(x.(T), err)
+			}
+
+			continue
+		}
+
+		m.out <- y
+	}
+
+	close(m.out)
+}
@@ -0,0 +1,55 @@
+package streams_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/sinks"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapErr(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       []string
+		expected []int
+		failed   []string
+	}{
+		{
+			name:     "atoi",
+			in:       []string{"1", "a", "3"},
+			expected: []int{1, 3},
+			failed:   []string{"a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := make(chan any, 3)
+			out := make(chan any, 3)
+
+			channels.Channel(tt.in, in)
+
+			failed := make([]string, 0)
+			recv := streams.MapErr(strconv.Atoi, func(x string, err error) {
+				require.ErrorIs(t, err, strconv.ErrSyntax)
+				failed = append(failed, x)
+			})
+
+			source := sources.NewChanSource(in)
+			sink := sinks.NewChanSink(out)
+
+			close(in)
+
+			err := source.Pipe(recv).To(sink)
+			require.NoError(t, err)
+
+			output := channels.Slice[int](out)
+			require.Equal(t, tt.expected, output)
+			require.Equal(t, tt.failed, failed)
+		})
+	}
+}
@@ -2,7 +2,6 @@ package nats
 
 import (
 	"context"
-	"fmt"
 	"sync"
 
 	"github.com/katallaxie/streams"
@@ -90,7 +89,6 @@ loop:
 
 		messages, err := j.cfg.Sub.Fetch(j.cfg.FetchBatchSize, j.cfg.PullOpts...)
 		if err != nil {
-			fmt.Println(err)
 			j.fail(err)
 			break loop
 		}
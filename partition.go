@@ -0,0 +1,8 @@
+package streams
+
+// PartitionBy partitions a stream into n outputs by the consistent hash of the key of each element.
+// Elements with the same key are always sent to the same output, so each output owns a stable share of the keys.
+// Elements of an unexpected type are sent to the first output. There is at least one output.
+func PartitionBy[T any](in Streamable, n int, key KeyFunc[T]) []Operatable {
+	return Balance(in, n, HashBy(key))
+}
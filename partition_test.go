@@ -0,0 +1,69 @@
+package streams_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartitionBy(t *testing.T) {
+	partition := func(n int, in []int) map[int]string {
+		src := make(chan any, len(in))
+		channels.Channel(in, src)
+		close(src)
+
+		outputs := streams.PartitionBy(sources.NewChanSource(src), n, func(x int) string { return strconv.Itoa(x % 10) })
+
+		named := make(map[string]streams.Operatable, n)
+		for i, flow := range outputs {
+			named[strconv.Itoa(i)] = flow
+		}
+
+		owners := make(map[int]string)
+		for name, elements := range collect(t, named) {
+			for _, x := range elements {
+				key := x.(int) % 10
+				if owner, ok := owners[key]; ok {
+					require.Equal(t, owner, name)
+				}
+				owners[key] = name
+			}
+		}
+
+		return owners
+	}
+
+	in := make([]int, 0, 100)
+	for i := range 100 {
+		in = append(in, i)
+	}
+
+	four := partition(4, in)
+	five := partition(5, in)
+	require.Len(t, four, 10)
+
+	moved := 0
+	for key, owner := range four {
+		if five[key] != owner {
+			moved++
+		}
+	}
+
+	assert.Less(t, moved, 6)
+}
+
+func TestPartitionByNoOutputs(t *testing.T) {
+	in := make(chan any, 3)
+	channels.Channel([]int{1, 2, 3}, in)
+	close(in)
+
+	outputs := streams.PartitionBy(sources.NewChanSource(in), 0, strconv.Itoa)
+	require.Len(t, outputs, 1)
+
+	require.Equal(t, map[string][]any{"0": {1, 2, 3}}, collect(t, map[string]streams.Operatable{"0": outputs[0]}))
+}
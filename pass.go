@@ -9,6 +9,7 @@ var (
 type PassThroughImpl struct {
 	in  chan any
 	out chan any
+	canceler
 }
 
 // PassThrough returns a new operator on pass-throughs.
@@ -19,8 +20,9 @@ func PassThrough() *PassThroughImpl {
 // NewPassThrough returns a new operator on pass-throughs.
 func NewPassThrough() *PassThroughImpl {
 	t := &PassThroughImpl{
-		in:  make(chan any),
-		out: make(chan any),
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
 	}
 
 	go t.attach()
@@ -57,8 +59,13 @@ func (p *PassThroughImpl) Pipe(c Operatable) Operatable {
 }
 
 func (p *PassThroughImpl) stream(r Receivable) {
+	done := doneOf(r)
 	for x := range p.out {
-		r.In() <- x
+		select {
+		case r.In() <- x:
+		case <-done:
+			p.cancel()
+		}
 	}
 
 	close(r.In())
@@ -0,0 +1,86 @@
+package streams
+
+var (
+	_ Streamable = (*PrependImpl)(nil)
+	_ Receivable = (*PrependImpl)(nil)
+)
+
+// PrependImpl emits a number of values before the incoming elements.
+type PrependImpl struct {
+	values []any
+	in     chan any
+	out    chan any
+	canceler
+}
+
+// Prepend returns a new operator that emits the values before the incoming elements.
+func Prepend(values ...any) *PrependImpl {
+	return NewPrepend(values...)
+}
+
+// NewPrepend returns a new operator that emits the values before the incoming elements.
+func NewPrepend(values ...any) *PrependImpl {
+	p := &PrependImpl{
+		values:   values,
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
+	}
+
+	go p.attach()
+
+	return p
+}
+
+// To streams data to the sink and waits for it to complete.
+func (p *PrependImpl) To(sink Sinkable) error {
+	p.stream(sink)
+
+	err := sink.Wait()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// In returns the input channel.
+func (p *PrependImpl) In() chan<- any {
+	return p.in
+}
+
+// Out returns the output channel.
+func (p *PrependImpl) Out() <-chan any {
+	return p.out
+}
+
+// Pipe pipes the output channel to the input channel.
+func (p *PrependImpl) Pipe(c Operatable) Operatable {
+	go p.stream(c)
+	return c
+}
+
+func (p *PrependImpl) stream(r Receivable) {
+	done := doneOf(r)
+	for x := range p.out {
+		select {
+		case r.In() <- x:
+		case <-done:
+			p.cancel()
+		}
+	}
+
+	close(r.In())
+}
+
+func (p *PrependImpl) attach() {
+	for _, x := range p.values {
+		p.out <- x
+	}
+
+	for x := range p.in {
+		p.out <- x
+	}
+
+	close(p.out)
+}
@@ -0,0 +1,53 @@
+package streams_test
+
+import (
+	"testing"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/sinks"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepend(t *testing.T) {
+	tests := []struct {
+		name     string
+		recv     streams.Operatable
+		in       []int
+		expected []int
+	}{
+		{
+			name:     "prepend",
+			in:       []int{3, 4},
+			expected: []int{1, 2, 3, 4},
+			recv:     streams.Prepend(1, 2),
+		},
+		{
+			name:     "empty",
+			in:       []int{},
+			expected: []int{1},
+			recv:     streams.Prepend(1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := make(chan any, 3)
+			out := make(chan any, 4)
+
+			channels.Channel(tt.in, in)
+
+			source := sources.NewChanSource(in)
+			sink := sinks.NewChanSink(out)
+
+			close(in)
+
+			err := source.Pipe(tt.recv).To(sink)
+			require.NoError(t, err)
+
+			output := channels.Slice[int](out)
+			require.Equal(t, tt.expected, output)
+		})
+	}
+}
@@ -13,14 +13,16 @@ type Reduce[T any] struct {
 	fn  ReduceFunc[T]
 	in  chan any
 	out chan any
+	canceler
 }
 
 // NewReduce returns a new operator on reduces.
 func NewReduce[T any](fn ReduceFunc[T]) *Reduce[T] {
 	t := &Reduce[T]{
-		fn:  fn,
-		in:  make(chan any),
-		out: make(chan any),
+		fn:       fn,
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
 	}
 
 	go t.attach()
@@ -57,8 +59,13 @@ func (r *Reduce[T]) Pipe(c Operatable) Operatable {
 }
 
 func (r *Reduce[T]) stream(recv Receivable) {
+	done := doneOf(recv)
 	for x := range r.out {
-		recv.In() <- x
+		select {
+		case recv.In() <- x:
+		case <-done:
+			r.cancel()
+		}
 	}
 
 	close(recv.In())
@@ -0,0 +1,90 @@
+package streams
+
+import (
+	"context"
+	"sync"
+)
+
+// RepeatForever repeats a source until it fails or the context is canceled.
+const RepeatForever = -1
+
+// SourceFactory creates a new subscription to a source that stops when the context is canceled.
+type SourceFactory func(context.Context) (Sourceable, error)
+
+var _ Sourceable = (*RepeatImpl)(nil)
+
+// RepeatImpl is a source that subscribes to a source a number of times.
+type RepeatImpl struct {
+	factory SourceFactory
+	n       int
+	out     chan any
+	err     error
+	errOnce sync.Once
+}
+
+// Repeat returns a new source that subscribes to a source n times or forever with RepeatForever.
+// The source stops subscribing when the context is canceled.
+func Repeat(ctx context.Context, factory SourceFactory, n int) *RepeatImpl {
+	return NewRepeat(ctx, factory, n)
+}
+
+// NewRepeat returns a new source that subscribes to a source n times or forever with RepeatForever.
+// The source stops subscribing when the context is canceled.
+func NewRepeat(ctx context.Context, factory SourceFactory, n int) *RepeatImpl {
+	r := &RepeatImpl{
+		factory: factory,
+		n:       n,
+		out:     make(chan any),
+	}
+
+	go r.attach(ctx)
+
+	return r
+}
+
+// Error returns the error.
+func (r *RepeatImpl) Error() error {
+	return r.err
+}
+
+func (r *RepeatImpl) fail(err error) {
+	r.errOnce.Do(func() {
+		r.err = err
+	})
+}
+
+// Out returns the output channel.
+func (r *RepeatImpl) Out() <-chan any {
+	return r.out
+}
+
+// Pipe pipes the output channel to the input channel.
+func (r *RepeatImpl) Pipe(c Operatable) Operatable {
+	Pipe(r, c)
+	return c
+}
+
+func (r *RepeatImpl) attach(ctx context.Context) {
+	defer close(r.out)
+
+	for i := 0; ctx.Err() == nil && (r.n == RepeatForever || i < r.n); i++ {
+		source, err := r.factory(ctx)
+		if err != nil {
+			r.fail(err)
+			return
+		}
+
+		for x := range source.Out() {
+			select {
+			case r.out <- x:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if err := source.Error(); err != nil {
+			r.fail(err)
+			return
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package streams_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/sinks"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepeat(t *testing.T) {
+	factory := func(context.Context) (streams.Sourceable, error) {
+		return sources.NewSeqSource(slices.Values([]int{1, 2}))
+	}
+
+	out := make(chan any, 6)
+
+	source := streams.Repeat(context.Background(), factory, 3)
+	err := source.Pipe(streams.PassThrough()).To(sinks.NewChanSink(out))
+	require.NoError(t, err)
+	require.NoError(t, source.Error())
+
+	require.Equal(t, []int{1, 2, 1, 2, 1, 2}, channels.Slice[int](out))
+}
+
+func TestRepeatForever(t *testing.T) {
+	factory := func(ctx context.Context) (streams.Sourceable, error) {
+		return sources.Range(ctx, 1, 3, 1)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := make(chan any, 5)
+
+	source := streams.Repeat(ctx, factory, streams.RepeatForever)
+	err := source.Pipe(streams.Take(5)).To(sinks.NewChanSink(out))
+	require.NoError(t, err)
+
+	cancel()
+	channels.Drain(source.Out())
+
+	require.Equal(t, []int{1, 2, 1, 2, 1}, channels.Slice[int](out))
+}
+
+func TestRepeatError(t *testing.T) {
+	errFactory := errors.New("factory")
+	calls := 0
+
+	factory := func(context.Context) (streams.Sourceable, error) {
+		calls++
+		if calls > 1 {
+			return nil, errFactory
+		}
+
+		return sources.NewSeqSource(slices.Values([]int{1}))
+	}
+
+	out := make(chan any, 1)
+
+	source := streams.Repeat(context.Background(), factory, streams.RepeatForever)
+	err := source.Pipe(streams.PassThrough()).To(sinks.NewChanSink(out))
+	require.NoError(t, err)
+	require.ErrorIs(t, source.Error(), errFactory)
+
+	require.Equal(t, []int{1}, channels.Slice[int](out))
+}
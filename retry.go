@@ -0,0 +1,187 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+var (
+	// ErrAttemptTimeout is returned when a single attempt exceeds the attempt timeout.
+	ErrAttemptTimeout = errors.New("streams: attempt timed out")
+	// ErrAttemptTimeoutUnsupported is returned by Retry and RetryDo for a policy with an attempt timeout,
+	// because an attempt without a context can not be canceled.
+	ErrAttemptTimeoutUnsupported = errors.New("streams: attempt timeout requires RetryContext or RetryDoContext")
+)
+
+// maxBackoff bounds the wait time if there is no MaxBackoff, so that it does not overflow.
+const maxBackoff = math.MaxInt64 / 2
+
+// RetryError is returned when a function still fails after all attempts.
+type RetryError struct {
+	// Attempts is the number of attempts that have been made.
+	Attempts int
+	// Err is the error of the last attempt.
+	Err error
+}
+
+// Error returns the error message.
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("streams: giving up after %d attempts: %v", e.Attempts, e.Err)
+}
+
+// Unwrap returns the error of the last attempt.
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// RetryPolicy configures how a failing function is retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	MaxAttempts int
+	// InitialBackoff is the wait time before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait time between retries.
+	MaxBackoff time.Duration
+	// Multiplier grows the wait time after each retry.
+	Multiplier float64
+	// Jitter randomizes the wait time by the given fraction, e.g. 0.2 for ±20%.
+	Jitter float64
+	// Retryable classifies errors that can be retried. All errors are retried if nil.
+	Retryable func(error) bool
+	// AttemptTimeout cancels the context of a single attempt of RetryContext and RetryDoContext.
+	// Retry and RetryDo fail with ErrAttemptTimeoutUnsupported if set. There is no limit if zero.
+	AttemptTimeout time.Duration
+}
+
+// DefaultRetryPolicy returns a default retry policy.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// Backoff returns the wait time after the given failed attempt.
+func (p *RetryPolicy) Backoff(attempt int) time.Duration {
+	limit := float64(maxBackoff)
+	if p.MaxBackoff > 0 {
+		limit = float64(p.MaxBackoff)
+	}
+
+	d := float64(p.InitialBackoff)
+	for i := 1; i < attempt && d < limit; i++ {
+		d *= max(p.Multiplier, 1)
+	}
+	d = min(d, limit)
+
+	if p.Jitter > 0 {
+		d += d * p.Jitter * (2*rand.Float64() - 1) //nolint:gosec // jitter does not need a secure random source
+	}
+
+	return time.Duration(min(d, maxBackoff))
+}
+
+// MapErrContextFunc is a fallible function that takes a context and an element and returns a new element.
+type MapErrContextFunc[T, R any] func(context.Context, T) (R, error)
+
+// DoContextFunc is a function that takes a context and is executed on an element.
+type DoContextFunc[T any] func(context.Context, T) error
+
+// Retry wraps a fallible function to be retried according to the policy.
+// Use RetryContext for a policy with an attempt timeout or to cancel the retries.
+func Retry[T, R any](fn MapErrFunc[T, R], policy *RetryPolicy) MapErrFunc[T, R] {
+	if policy.AttemptTimeout > 0 {
+		return func(T) (R, error) {
+			var r R
+			return r, ErrAttemptTimeoutUnsupported
+		}
+	}
+
+	return RetryContext(context.Background(), func(_ context.Context, x T) (R, error) {
+		return fn(x)
+	}, policy)
+}
+
+// RetryDo wraps a function executed on the element to be retried according to the policy.
+// Use RetryDoContext for a policy with an attempt timeout or to cancel the retries.
+func RetryDo[T any](fn DoFunc[T], policy *RetryPolicy) DoFunc[T] {
+	r := Retry(func(x T) (struct{}, error) {
+		return struct{}{}, fn(x)
+	}, policy)
+
+	return func(x T) error {
+		_, err := r(x)
+		return err
+	}
+}
+
+// RetryContext wraps a fallible function to be retried according to the policy.
+// The context of an attempt is derived from ctx and canceled after the attempt timeout.
+// The retries stop if ctx is canceled.
+func RetryContext[T, R any](ctx context.Context, fn MapErrContextFunc[T, R], policy *RetryPolicy) MapErrFunc[T, R] {
+	return func(x T) (R, error) {
+		return retry(ctx, policy, func(ctx context.Context) (R, error) {
+			return fn(ctx, x)
+		})
+	}
+}
+
+// RetryDoContext wraps a function executed on the element to be retried according to the policy.
+// The context of an attempt is derived from ctx and canceled after the attempt timeout.
+// The retries stop if ctx is canceled.
+func RetryDoContext[T any](ctx context.Context, fn DoContextFunc[T], policy *RetryPolicy) DoFunc[T] {
+	r := RetryContext(ctx, func(ctx context.Context, x T) (struct{}, error) {
+		return struct{}{}, fn(ctx, x)
+	}, policy)
+
+	return func(x T) error {
+		_, err := r(x)
+		return err
+	}
+}
+
+func retry[R any](ctx context.Context, p *RetryPolicy, fn func(context.Context) (R, error)) (R, error) {
+	for attempt := 1; ; attempt++ {
+		r, err := attemptWithTimeout(ctx, p.AttemptTimeout, fn)
+		if err == nil {
+			return r, nil
+		}
+
+		if attempt >= p.MaxAttempts || (p.Retryable != nil && !p.Retryable(err)) || ctx.Err() != nil {
+			return r, &RetryError{Attempts: attempt, Err: err}
+		}
+
+		timer := time.NewTimer(p.Backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return r, &RetryError{Attempts: attempt, Err: fmt.Errorf("%w: %w", ctx.Err(), err)}
+		case <-timer.C:
+		}
+	}
+}
+
+// attemptWithTimeout runs an attempt with a context that is canceled after the timeout.
+// The attempt is not abandoned, so that slow attempts do not pile up.
+func attemptWithTimeout[R any](parent context.Context, timeout time.Duration, fn func(context.Context) (R, error)) (R, error) {
+	if timeout <= 0 {
+		return fn(parent)
+	}
+
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+
+	r, err := fn(ctx)
+	if err != nil && parent.Err() == nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return r, fmt.Errorf("%w: %w", ErrAttemptTimeout, err)
+	}
+
+	return r, err
+}
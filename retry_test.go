@@ -0,0 +1,147 @@
+package streams_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/katallaxie/streams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTransient = errors.New("transient")
+
+func failing(n int) (streams.MapErrFunc[int, int], *int) {
+	calls := 0
+
+	return func(x int) (int, error) {
+		calls++
+		if calls <= n {
+			return 0, errTransient
+		}
+
+		return x * 2, nil
+	}, &calls
+}
+
+func TestRetry(t *testing.T) {
+	policy := &streams.RetryPolicy{MaxAttempts: 3}
+
+	tests := []struct {
+		name     string
+		failures int
+		policy   *streams.RetryPolicy
+		expected int
+		attempts int
+		err      error
+	}{
+		{
+			name:     "success",
+			failures: 0,
+			policy:   policy,
+			expected: 2,
+			attempts: 1,
+		},
+		{
+			name:     "success after retries",
+			failures: 2,
+			policy:   policy,
+			expected: 2,
+			attempts: 3,
+		},
+		{
+			name:     "exhausted",
+			failures: 3,
+			policy:   policy,
+			attempts: 3,
+			err:      errTransient,
+		},
+		{
+			name:     "not retryable",
+			failures: 3,
+			policy: &streams.RetryPolicy{
+				MaxAttempts: 3,
+				Retryable:   func(error) bool { return false },
+			},
+			attempts: 1,
+			err:      errTransient,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn, calls := failing(tt.failures)
+
+			x, err := streams.Retry(fn, tt.policy)(1)
+			require.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.expected, x)
+			assert.Equal(t, tt.attempts, *calls)
+
+			var retryErr *streams.RetryError
+			if tt.err != nil {
+				require.ErrorAs(t, err, &retryErr)
+				assert.Equal(t, tt.attempts, retryErr.Attempts)
+			}
+		})
+	}
+}
+
+func TestRetryAttemptTimeout(t *testing.T) {
+	policy := &streams.RetryPolicy{MaxAttempts: 2, AttemptTimeout: time.Millisecond}
+
+	attempts := 0
+	err := streams.RetryDoContext(context.Background(), func(ctx context.Context, _ int) error {
+		attempts++
+		<-ctx.Done()
+
+		return ctx.Err()
+	}, policy)(1)
+	require.ErrorIs(t, err, streams.ErrAttemptTimeout)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, 2, attempts)
+
+	err = streams.RetryDo(func(int) error { return nil }, policy)(1)
+	require.ErrorIs(t, err, streams.ErrAttemptTimeoutUnsupported)
+}
+
+func TestRetryContextCancel(t *testing.T) {
+	errFailed := errors.New("failed")
+	policy := &streams.RetryPolicy{MaxAttempts: 10, InitialBackoff: time.Hour}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	err := streams.RetryDoContext(ctx, func(context.Context, int) error {
+		return errFailed
+	}, policy)(1)
+
+	var retryErr *streams.RetryError
+	require.ErrorAs(t, err, &retryErr)
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorIs(t, err, errFailed)
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &streams.RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     300 * time.Millisecond,
+		Multiplier:     2,
+	}
+
+	assert.Equal(t, 100*time.Millisecond, policy.Backoff(1))
+	assert.Equal(t, 200*time.Millisecond, policy.Backoff(2))
+	assert.Equal(t, 300*time.Millisecond, policy.Backoff(3))
+
+	policy.Jitter = 0.5
+	for range 10 {
+		d := policy.Backoff(1)
+		assert.GreaterOrEqual(t, d, 50*time.Millisecond)
+		assert.LessOrEqual(t, d, 150*time.Millisecond)
+	}
+
+	// the wait time does not overflow without a maximum
+	policy = &streams.RetryPolicy{InitialBackoff: time.Second, Multiplier: 2, Jitter: 0.2}
+	assert.Positive(t, policy.Backoff(10000))
+}
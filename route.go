@@ -0,0 +1,52 @@
+package streams
+
+// DefaultRoute is the name of the output for elements without a matching route.
+const DefaultRoute = "default"
+
+// RouteFunc returns the name of the output for an element.
+type RouteFunc[T any] func(T) string
+
+// MultiRouteFunc returns the names of all outputs for an element.
+type MultiRouteFunc[T any] func(T) []string
+
+// Route routes each element of a stream to one of the named outputs.
+// Elements with an unknown route or of an unexpected type are sent to the DefaultRoute output.
+func Route[T any](in Streamable, fn RouteFunc[T], outputs ...string) map[string]Operatable {
+	return RouteMulti(in, func(x T) []string { return []string{fn(x)} }, outputs...)
+}
+
+// RouteMulti routes each element of a stream to any number of the named outputs.
+// Elements without a known route or of an unexpected type are sent to the DefaultRoute output.
+func RouteMulti[T any](in Streamable, fn MultiRouteFunc[T], outputs ...string) map[string]Operatable {
+	out := make(map[string]Operatable, len(outputs)+1)
+	out[DefaultRoute] = PassThrough()
+
+	for _, name := range outputs {
+		out[name] = PassThrough()
+	}
+
+	go func() {
+		for x := range in.Out() {
+			routed := false
+
+			if v, ok := x.(T); ok {
+				for _, name := range fn(v) {
+					if flow, ok := out[name]; ok {
+						flow.In() <- x
+						routed = true
+					}
+				}
+			}
+
+			if !routed {
+				out[DefaultRoute].In() <- x
+			}
+		}
+
+		for _, flow := range out {
+			close(flow.In())
+		}
+	}()
+
+	return out
+}
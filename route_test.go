@@ -0,0 +1,54 @@
+package streams_test
+
+import (
+	"testing"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoute(t *testing.T) {
+	in := make(chan any, 5)
+	channels.Channel([]any{1, 2, 3, "a", 4}, in)
+	close(in)
+
+	outputs := streams.Route(sources.NewChanSource(in), func(x int) string {
+		switch {
+		case x%2 == 0:
+			return "even"
+		case x == 3:
+			return "unknown"
+		default:
+			return "odd"
+		}
+	}, "even", "odd")
+
+	require.Len(t, outputs, 3)
+	require.Equal(t, map[string][]any{
+		"even":               {2, 4},
+		"odd":                {1},
+		streams.DefaultRoute: {3, "a"},
+	}, collect(t, outputs))
+}
+
+func TestRouteMulti(t *testing.T) {
+	in := make(chan any, 3)
+	channels.Channel([]any{1, 2, 3}, in)
+	close(in)
+
+	outputs := streams.RouteMulti(sources.NewChanSource(in), func(x int) []string {
+		if x == 2 {
+			return []string{"a", "b"}
+		}
+
+		return []string{"a"}
+	}, "a", "b")
+
+	require.Equal(t, map[string][]any{
+		"a":                  {1, 2, 3},
+		"b":                  {2},
+		streams.DefaultRoute: {},
+	}, collect(t, outputs))
+}
@@ -0,0 +1,89 @@
+package streams
+
+// ScanFunc combines the accumulator with the current element and returns a new accumulator.
+type ScanFunc[T, A any] func(A, T) A
+
+var (
+	_ Streamable = (*ScanImpl[any, any])(nil)
+	_ Receivable = (*ScanImpl[any, any])(nil)
+)
+
+// ScanImpl folds the elements into an accumulator starting from a seed and emits every intermediate accumulator.
+type ScanImpl[T, A any] struct {
+	seed A
+	fn   ScanFunc[T, A]
+	in   chan any
+	out  chan any
+	canceler
+}
+
+// Scan returns a new operator that emits every intermediate accumulator.
+func Scan[T, A any](seed A, fn ScanFunc[T, A]) *ScanImpl[T, A] {
+	return NewScan(seed, fn)
+}
+
+// NewScan returns a new operator that emits every intermediate accumulator.
+func NewScan[T, A any](seed A, fn ScanFunc[T, A]) *ScanImpl[T, A] {
+	s := &ScanImpl[T, A]{
+		seed:     seed,
+		fn:       fn,
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
+	}
+
+	go s.attach()
+
+	return s
+}
+
+// To streams data to the sink and waits for it to complete.
+func (s *ScanImpl[T, A]) To(sink Sinkable) error {
+	s.stream(sink)
+
+	err := sink.Wait()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// In returns the input channel.
+func (s *ScanImpl[T, A]) In() chan<- any {
+	return s.in
+}
+
+// Out returns the output channel.
+func (s *ScanImpl[T, A]) Out() <-chan any {
+	return s.out
+}
+
+// Pipe pipes the output channel to the input channel.
+func (s *ScanImpl[T, A]) Pipe(c Operatable) Operatable {
+	go s.stream(c)
+	return c
+}
+
+func (s *ScanImpl[T, A]) stream(r Receivable) {
+	done := doneOf(r)
+	for x := range s.out {
+		select {
+		case r.In() <- x:
+		case <-done:
+			s.cancel()
+		}
+	}
+
+	close(r.In())
+}
+
+func (s *ScanImpl[T, A]) attach() {
+	acc := s.seed
+	for x := range s.in {
+		acc = s.fn(acc, x.(T))
+		s.out <- acc
+	}
+
+	close(s.out)
+}
@@ -0,0 +1,81 @@
+package streams_test
+
+import (
+	"testing"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/sinks"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/require"
+)
+
+func count(acc map[string]int, s string) map[string]int {
+	next := make(map[string]int, len(acc)+1)
+	for k, v := range acc {
+		next[k] = v
+	}
+	next[s]++
+
+	return next
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		name     string
+		recv     streams.Operatable
+		in       []int
+		expected []int
+	}{
+		{
+			name:     "sum",
+			in:       []int{1, 2, 3},
+			expected: []int{1, 3, 6},
+			recv:     streams.Scan(0, sum),
+		},
+		{
+			name:     "sum with zero values",
+			in:       []int{0, 0, 1},
+			expected: []int{10, 10, 11},
+			recv:     streams.Scan(10, sum),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := make(chan any, 3)
+			out := make(chan any, 3)
+
+			channels.Channel(tt.in, in)
+
+			source := sources.NewChanSource(in)
+			sink := sinks.NewChanSink(out)
+
+			close(in)
+
+			err := source.Pipe(tt.recv).To(sink)
+			require.NoError(t, err)
+
+			output := channels.Slice[int](out)
+			require.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func TestScanDifferentType(t *testing.T) {
+	in := make(chan any, 3)
+	out := make(chan any, 3)
+
+	channels.Channel([]string{"a", "b", "a"}, in)
+
+	source := sources.NewChanSource(in)
+	sink := sinks.NewChanSink(out)
+
+	close(in)
+
+	err := source.Pipe(streams.Scan(map[string]int{}, count)).To(sink)
+	require.NoError(t, err)
+
+	output := channels.Slice[map[string]int](out)
+	require.Equal(t, []map[string]int{{"a": 1}, {"a": 1, "b": 1}, {"a": 2, "b": 1}}, output)
+}
@@ -0,0 +1,359 @@
+package sinks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/katallaxie/streams"
+)
+
+var (
+	_ streams.Sinkable = (*EventStream)(nil)
+	_ http.Handler     = (*EventStream)(nil)
+)
+
+// StreamFormat is the wire format of an event stream.
+type StreamFormat int
+
+const (
+	// FormatSSE streams the elements as Server-Sent Events.
+	FormatSSE StreamFormat = iota
+	// FormatNDJSON streams the elements as newline delimited JSON.
+	// Strings and bytes that are not valid JSON are encoded as JSON strings.
+	FormatNDJSON
+)
+
+// ContentType returns the content type of the format.
+func (f StreamFormat) ContentType() string {
+	if f == FormatNDJSON {
+		return "application/x-ndjson"
+	}
+
+	return "text/event-stream"
+}
+
+// SlowClientPolicy is the policy for clients that have a full buffer.
+type SlowClientPolicy int
+
+const (
+	// DisconnectSlowClient disconnects the client, it can resume with Last-Event-ID.
+	DisconnectSlowClient SlowClientPolicy = iota
+	// DropSlowClientEvents drops the events for the client.
+	DropSlowClientEvents
+)
+
+// Event is an element with an event name.
+type Event struct {
+	// Name is the name of the event. Line breaks are removed.
+	Name string
+	// Data is the data of the event.
+	Data any
+}
+
+// EventStreamConfig holds the configuration for an event stream sink.
+type EventStreamConfig struct {
+	// Format is the default format, clients can negotiate the format with the Accept header.
+	Format StreamFormat
+	// ClientBuffer is the number of events that are buffered per client.
+	ClientBuffer int
+	// Policy is the policy for clients with a full buffer.
+	Policy SlowClientPolicy
+	// ReplayBuffer is the number of events that are kept to resume with Last-Event-ID.
+	ReplayBuffer int
+	// KeepAlive is the interval to send comments to idle SSE clients, zero disables it.
+	KeepAlive time.Duration
+	// WriteTimeout is the deadline of a write to a client, zero disables it.
+	WriteTimeout time.Duration
+}
+
+// DefaultEventStreamConfig returns a default event stream configuration.
+func DefaultEventStreamConfig() *EventStreamConfig {
+	return &EventStreamConfig{
+		ClientBuffer: 64,
+		ReplayBuffer: 1024,
+		KeepAlive:    15 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+}
+
+type event struct {
+	id   uint64
+	name string
+	data []byte
+}
+
+type client struct {
+	events chan event
+}
+
+// EventStream is a sink that streams the elements to every connected HTTP client.
+// Byte slices and strings are written as they are, other elements are encoded as JSON.
+type EventStream struct {
+	cfg     *EventStreamConfig
+	in      chan any
+	done    chan struct{}
+	mu      sync.Mutex
+	clients map[*client]struct{}
+	replay  []event
+	id      uint64
+	closed  bool
+}
+
+// NewEventStream returns a new event stream sink.
+func NewEventStream(cfg *EventStreamConfig) *EventStream {
+	if cfg == nil {
+		cfg = DefaultEventStreamConfig()
+	}
+
+	s := &EventStream{
+		cfg:     cfg,
+		in:      make(chan any),
+		done:    make(chan struct{}),
+		clients: make(map[*client]struct{}),
+	}
+
+	go s.attach()
+
+	return s
+}
+
+// In returns the input channel.
+func (s *EventStream) In() chan<- any {
+	return s.in
+}
+
+// Wait waits for the sink to complete.
+func (s *EventStream) Wait() error {
+	<-s.done
+
+	return nil
+}
+
+// Clients returns the number of connected clients.
+func (s *EventStream) Clients() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.clients)
+}
+
+func (s *EventStream) attach() {
+	defer close(s.done)
+
+	for x := range s.in {
+		e, err := encodeEvent(x)
+		if err != nil {
+			continue
+		}
+
+		s.publish(e)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for c := range s.clients {
+		s.remove(c)
+	}
+}
+
+func (s *EventStream) publish(e event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.id++
+	e.id = s.id
+
+	if s.cfg.ReplayBuffer > 0 {
+		if len(s.replay) == s.cfg.ReplayBuffer {
+			s.replay = append(s.replay[:0], s.replay[1:]...)
+		}
+		s.replay = append(s.replay, e)
+	}
+
+	for c := range s.clients {
+		select {
+		case c.events <- e:
+		default:
+			if s.cfg.Policy == DisconnectSlowClient {
+				s.remove(c)
+			}
+		}
+	}
+}
+
+// remove removes the client, the lock has to be held.
+func (s *EventStream) remove(c *client) {
+	if _, ok := s.clients[c]; ok {
+		delete(s.clients, c)
+		close(c.events)
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (s *EventStream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if _, ok := w.(http.Flusher); !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	rc := http.NewResponseController(w)
+
+	write := func(b []byte) error {
+		if s.cfg.WriteTimeout > 0 {
+			_ = rc.SetWriteDeadline(time.Now().Add(s.cfg.WriteTimeout))
+		}
+
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+
+		return rc.Flush()
+	}
+
+	format := s.cfg.Format
+	switch accept := r.Header.Get("Accept"); {
+	case strings.Contains(accept, FormatNDJSON.ContentType()):
+		format = FormatNDJSON
+	case strings.Contains(accept, FormatSSE.ContentType()):
+		format = FormatSSE
+	}
+
+	last := r.Header.Get("Last-Event-ID")
+	if last == "" {
+		last = r.URL.Query().Get("lastEventId")
+	}
+	lastID, _ := strconv.ParseUint(last, 10, 64)
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent) // tells SSE clients to stop reconnecting
+
+		return
+	}
+
+	replay := []event{}
+	if last != "" {
+		for _, e := range s.replay {
+			if e.id > lastID {
+				replay = append(replay, e)
+			}
+		}
+	}
+
+	c := &client{events: make(chan event, s.cfg.ClientBuffer)}
+	s.clients[c] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.remove(c)
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for _, e := range replay {
+		if err := write(format.encode(e)); err != nil {
+			return
+		}
+	}
+	_ = rc.Flush()
+
+	var keepAlive <-chan time.Time
+	if s.cfg.KeepAlive > 0 && format == FormatSSE {
+		ticker := time.NewTicker(s.cfg.KeepAlive)
+		defer ticker.Stop()
+		keepAlive = ticker.C
+	}
+
+	for {
+		select {
+		case e, ok := <-c.events:
+			if !ok {
+				return
+			}
+
+			if err := write(format.encode(e)); err != nil {
+				return
+			}
+		case <-keepAlive:
+			if err := write([]byte(":\n\n")); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func (f StreamFormat) encode(e event) []byte {
+	var b bytes.Buffer
+
+	if f == FormatNDJSON {
+		// compacting removes the line breaks of valid JSON
+		if err := json.Compact(&b, e.data); err != nil {
+			b.Reset()
+			data, _ := json.Marshal(string(e.data))
+			b.Write(data)
+		}
+		b.WriteByte('\n')
+
+		return b.Bytes()
+	}
+
+	fmt.Fprintf(&b, "id: %d\n", e.id)
+	if e.name != "" {
+		fmt.Fprintf(&b, "event: %s\n", e.name)
+	}
+
+	if len(e.data) == 0 {
+		b.WriteString("data: \n")
+	}
+
+	// SSE parsers break lines at CRLF, LF and a lone CR
+	data := bytes.ReplaceAll(bytes.ReplaceAll(e.data, []byte("\r\n"), []byte("\n")), []byte("\r"), []byte("\n"))
+	for line := range bytes.Lines(data) {
+		fmt.Fprintf(&b, "data: %s\n", bytes.TrimSuffix(line, []byte("\n")))
+	}
+	b.WriteByte('\n')
+
+	return b.Bytes()
+}
+
+// lineBreaks removes the line breaks that would inject fields into an SSE event.
+var lineBreaks = strings.NewReplacer("\r", "", "\n", "")
+
+func encodeEvent(x any) (event, error) {
+	e := event{}
+
+	if ev, ok := x.(Event); ok {
+		e.name = lineBreaks.Replace(ev.Name)
+		x = ev.Data
+	}
+
+	switch data := x.(type) {
+	case []byte:
+		e.data = data
+	case string:
+		e.data = []byte(data)
+	default:
+		b, err := json.Marshal(data)
+		if err != nil {
+			return e, err
+		}
+		e.data = b
+	}
+
+	return e, nil
+}
@@ -0,0 +1,136 @@
+package sinks_test
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/katallaxie/streams/sinks"
+	"github.com/stretchr/testify/require"
+)
+
+func connect(t *testing.T, url string, header map[string]string) (*http.Response, *bufio.Reader) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { res.Body.Close() })
+
+	return res, bufio.NewReader(res.Body)
+}
+
+func waitClients(t *testing.T, s *sinks.EventStream, n int) {
+	t.Helper()
+
+	require.Eventually(t, func() bool { return s.Clients() == n }, 5*time.Second, time.Millisecond)
+}
+
+func readEvent(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+
+	lines := []string{}
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+
+		if line == "\n" {
+			return strings.Join(lines, "")
+		}
+		lines = append(lines, line)
+	}
+}
+
+func TestEventStream_SSE(t *testing.T) {
+	s := sinks.NewEventStream(nil)
+
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	res, r := connect(t, srv.URL, nil)
+	require.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+	waitClients(t, s, 1)
+
+	s.In() <- "foo\nbar"
+	s.In() <- sinks.Event{Name: "update", Data: map[string]int{"a": 1}}
+	s.In() <- sinks.Event{Name: "up\r\ndata: x", Data: "foo"}
+	s.In() <- "foo\rid: 999\revent: evil\r\nbar"
+
+	require.Equal(t, "id: 1\ndata: foo\ndata: bar\n", readEvent(t, r))
+	require.Equal(t, "id: 2\nevent: update\ndata: {\"a\":1}\n", readEvent(t, r))
+	require.Equal(t, "id: 3\nevent: updata: x\ndata: foo\n", readEvent(t, r))
+	require.Equal(t, "id: 4\ndata: foo\ndata: id: 999\ndata: event: evil\ndata: bar\n", readEvent(t, r))
+
+	// resume from the replay buffer
+	_, r = connect(t, srv.URL, map[string]string{"Last-Event-ID": "1"})
+	require.Equal(t, "id: 2\nevent: update\ndata: {\"a\":1}\n", readEvent(t, r))
+	require.Equal(t, "id: 3\nevent: updata: x\ndata: foo\n", readEvent(t, r))
+	require.Equal(t, "id: 4\ndata: foo\ndata: id: 999\ndata: event: evil\ndata: bar\n", readEvent(t, r))
+
+	close(s.In())
+	require.NoError(t, s.Wait())
+	waitClients(t, s, 0)
+}
+
+func TestEventStream_NDJSON(t *testing.T) {
+	s := sinks.NewEventStream(nil)
+
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+	defer close(s.In())
+
+	res, r := connect(t, srv.URL, map[string]string{"Accept": "application/x-ndjson"})
+	require.Equal(t, "application/x-ndjson", res.Header.Get("Content-Type"))
+	waitClients(t, s, 1)
+
+	s.In() <- map[string]int{"a": 1}
+	s.In() <- "foo\nbar"
+	s.In() <- []byte("{\n\"b\": 2\n}")
+
+	for _, expected := range []string{"{\"a\":1}\n", "\"foo\\nbar\"\n", "{\"b\":2}\n"} {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		require.Equal(t, expected, line)
+	}
+}
+
+func TestEventStream_SlowClient(t *testing.T) {
+	cfg := sinks.DefaultEventStreamConfig()
+	cfg.ClientBuffer = 1
+	cfg.WriteTimeout = 100 * time.Millisecond
+
+	s := sinks.NewEventStream(cfg)
+
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+	defer close(s.In())
+
+	_, _ = connect(t, srv.URL, nil)
+	waitClients(t, s, 1)
+
+	// the client does not read, so its buffer fills up eventually
+	payload := strings.Repeat("x", 1<<16)
+	require.Eventually(t, func() bool {
+		s.In() <- payload
+		return s.Clients() == 0
+	}, 5*time.Second, time.Millisecond)
+}
+
+func TestEventStream_Closed(t *testing.T) {
+	s := sinks.NewEventStream(nil)
+	close(s.In())
+	require.NoError(t, s.Wait())
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusNoContent, rec.Code)
+}
@@ -0,0 +1,214 @@
+package sinks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+)
+
+var _ streams.Sinkable = (*DialSink)(nil)
+
+// DialConfig holds the configuration for a dial sink.
+type DialConfig struct {
+	// Dialer is the dialer to connect to the address.
+	Dialer *net.Dialer
+	// Reconnect is the policy to reconnect and write an element again.
+	// MaxAttempts is the number of attempts per element, it is unlimited if zero.
+	Reconnect *streams.RetryPolicy
+	// WriteTimeout is the deadline of a write, zero disables it.
+	WriteTimeout time.Duration
+	// Delimiter is appended to each element.
+	Delimiter []byte
+}
+
+// DefaultDialConfig returns a default dial configuration.
+func DefaultDialConfig() *DialConfig {
+	return &DialConfig{
+		Dialer: &net.Dialer{Timeout: 10 * time.Second},
+		Reconnect: &streams.RetryPolicy{
+			InitialBackoff: 100 * time.Millisecond,
+			MaxBackoff:     10 * time.Second,
+			Multiplier:     2,
+			Jitter:         0.2,
+		},
+		WriteTimeout: 10 * time.Second,
+	}
+}
+
+// DefaultTCPConfig returns a default dial configuration with newline delimited elements.
+func DefaultTCPConfig() *DialConfig {
+	cfg := DefaultDialConfig()
+	cfg.Delimiter = []byte("\n")
+
+	return cfg
+}
+
+// DialSink is a sink that writes the elements to a network connection and reconnects if a write fails.
+// Byte slices, strings and fmt.Stringer are written, other elements are ignored.
+// The sink stops reconnecting and fails when the context is canceled.
+type DialSink struct {
+	network string
+	addr    string
+	cfg     *DialConfig
+	conn    net.Conn
+	in      chan any
+	done    chan struct{}
+	err     error
+	errOnce sync.Once
+}
+
+// NewTCPSink returns a new sink that writes the elements to a TCP address.
+// The configuration defaults to DefaultTCPConfig if nil.
+func NewTCPSink(ctx context.Context, addr string, cfg *DialConfig) *DialSink {
+	if cfg == nil {
+		cfg = DefaultTCPConfig()
+	}
+
+	return NewDialSink(ctx, "tcp", addr, cfg)
+}
+
+// NewUDPSink returns a new sink that writes each element as a datagram to a UDP address.
+func NewUDPSink(ctx context.Context, addr string, cfg *DialConfig) *DialSink {
+	return NewDialSink(ctx, "udp", addr, cfg)
+}
+
+// NewDialSink returns a new sink that writes the elements to the network address.
+// A nil dialer or reconnect policy is replaced by the default.
+func NewDialSink(ctx context.Context, network, addr string, cfg *DialConfig) *DialSink {
+	defaults := DefaultDialConfig()
+	if cfg == nil {
+		cfg = defaults
+	}
+
+	c := *cfg
+	if c.Dialer == nil {
+		c.Dialer = defaults.Dialer
+	}
+
+	if c.Reconnect == nil {
+		c.Reconnect = defaults.Reconnect
+	}
+
+	s := &DialSink{
+		network: network,
+		addr:    addr,
+		cfg:     &c,
+		in:      make(chan any),
+		done:    make(chan struct{}),
+	}
+
+	go s.attach(ctx)
+
+	return s
+}
+
+// Error returns the error.
+func (s *DialSink) Error() error {
+	return s.err
+}
+
+func (s *DialSink) fail(err error) {
+	s.errOnce.Do(func() {
+		s.err = err
+	})
+}
+
+// In returns the input channel.
+func (s *DialSink) In() chan<- any {
+	return s.in
+}
+
+// Wait waits for the sink to complete and returns the error.
+func (s *DialSink) Wait() error {
+	<-s.done
+
+	return s.err
+}
+
+func (s *DialSink) attach(ctx context.Context) {
+	defer close(s.done)
+	defer s.close()
+
+	for msg := range s.in {
+		var bb []byte
+		switch message := msg.(type) {
+		case []byte:
+			bb = message
+		case string:
+			bb = []byte(message)
+		case fmt.Stringer:
+			bb = []byte(message.String())
+		default:
+			continue
+		}
+
+		if len(s.cfg.Delimiter) > 0 {
+			bb = append(bb[:len(bb):len(bb)], s.cfg.Delimiter...)
+		}
+
+		if err := s.write(ctx, bb); err != nil {
+			s.fail(err)
+			channels.Drain(s.in)
+
+			return
+		}
+	}
+}
+
+func (s *DialSink) write(ctx context.Context, b []byte) error {
+	policy := s.cfg.Reconnect
+
+	for attempt := 1; ; attempt++ {
+		err := s.writeConn(ctx, b)
+		if err == nil {
+			return nil
+		}
+		s.close()
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if (policy.Retryable != nil && !policy.Retryable(err)) || (policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts) {
+			return &streams.RetryError{Attempts: attempt, Err: err}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(policy.Backoff(attempt)):
+		}
+	}
+}
+
+func (s *DialSink) writeConn(ctx context.Context, b []byte) error {
+	if s.conn == nil {
+		conn, err := s.cfg.Dialer.DialContext(ctx, s.network, s.addr)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	if s.cfg.WriteTimeout > 0 {
+		if err := s.conn.SetWriteDeadline(time.Now().Add(s.cfg.WriteTimeout)); err != nil {
+			return err
+		}
+	}
+
+	_, err := s.conn.Write(b)
+
+	return err
+}
+
+func (s *DialSink) close() {
+	if s.conn != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+}
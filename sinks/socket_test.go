@@ -0,0 +1,108 @@
+package sinks_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/katallaxie/streams/sinks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTCPSink_Reconnect(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	cfg := sinks.DefaultTCPConfig()
+	cfg.Reconnect.InitialBackoff = 10 * time.Millisecond
+	cfg.Reconnect.MaxBackoff = 10 * time.Millisecond
+
+	sink := sinks.NewTCPSink(context.Background(), addr, cfg)
+
+	// the sink retries until the listener is up
+	go func() {
+		sink.In() <- "foo"
+		sink.In() <- []byte("bar")
+		close(sink.In())
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	ln, err = net.Listen("tcp", addr)
+	require.NoError(t, err)
+	defer ln.Close()
+
+	conn, err := ln.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+
+	line, err := r.ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "foo\n", line)
+
+	line, err = r.ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "bar\n", line)
+
+	require.NoError(t, sink.Wait())
+}
+
+func TestTCPSink_Error(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	cfg := sinks.DefaultDialConfig()
+	cfg.Reconnect.InitialBackoff = time.Millisecond
+	cfg.Reconnect.MaxAttempts = 2
+
+	sink := sinks.NewTCPSink(context.Background(), addr, cfg)
+	sink.In() <- "foo"
+	sink.In() <- "bar"
+	close(sink.In())
+
+	require.Error(t, sink.Wait())
+	require.Error(t, sink.Error())
+}
+
+func TestTCPSink_Cancel(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// the sink retries forever until the context is canceled
+	sink := sinks.NewTCPSink(ctx, addr, nil)
+	sink.In() <- "foo"
+	cancel()
+	close(sink.In())
+
+	require.ErrorIs(t, sink.Wait(), context.Canceled)
+}
+
+func TestUDPSink(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	sink := sinks.NewUDPSink(context.Background(), conn.LocalAddr().String(), nil)
+	sink.In() <- "foo"
+	close(sink.In())
+	require.NoError(t, sink.Wait())
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	buf := make([]byte, 16)
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	require.Equal(t, "foo", string(buf[:n]))
+}
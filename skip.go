@@ -10,6 +10,7 @@ type SkipImpl struct {
 	n   int
 	in  chan any
 	out chan any
+	canceler
 }
 
 // Skip returns a new operator that skips the first n elements.
@@ -20,9 +21,10 @@ func Skip(n int) *SkipImpl {
 // NewSkip returns a new operator on skips.
 func NewSkip(n int) *SkipImpl {
 	t := &SkipImpl{
-		n:   n,
-		in:  make(chan any),
-		out: make(chan any),
+		n:        n,
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
 	}
 
 	go t.attach()
@@ -59,8 +61,13 @@ func (s *SkipImpl) Pipe(c Operatable) Operatable {
 }
 
 func (s *SkipImpl) stream(recv Receivable) {
+	done := doneOf(recv)
 	for x := range s.out {
-		recv.In() <- x
+		select {
+		case recv.In() <- x:
+		case <-done:
+			s.cancel()
+		}
 	}
 
 	close(recv.In())
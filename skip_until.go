@@ -0,0 +1,103 @@
+package streams
+
+var (
+	_ Streamable = (*SkipUntilImpl)(nil)
+	_ Receivable = (*SkipUntilImpl)(nil)
+)
+
+// SkipUntilImpl skips elements until a signal stream emits and passes all elements afterwards.
+type SkipUntilImpl struct {
+	other Streamable
+	in    chan any
+	out   chan any
+	canceler
+}
+
+// SkipUntil returns a new operator that skips elements until the other stream emits.
+func SkipUntil(other Streamable) *SkipUntilImpl {
+	return NewSkipUntil(other)
+}
+
+// NewSkipUntil returns a new operator that skips elements until the other stream emits.
+func NewSkipUntil(other Streamable) *SkipUntilImpl {
+	s := &SkipUntilImpl{
+		other:    other,
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
+	}
+
+	go s.attach()
+
+	return s
+}
+
+// To streams data to the sink and waits for it to complete.
+func (s *SkipUntilImpl) To(sink Sinkable) error {
+	s.stream(sink)
+
+	err := sink.Wait()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// In returns the input channel.
+func (s *SkipUntilImpl) In() chan<- any {
+	return s.in
+}
+
+// Out returns the output channel.
+func (s *SkipUntilImpl) Out() <-chan any {
+	return s.out
+}
+
+// Pipe pipes the output channel to the input channel.
+func (s *SkipUntilImpl) Pipe(c Operatable) Operatable {
+	go s.stream(c)
+	return c
+}
+
+func (s *SkipUntilImpl) stream(r Receivable) {
+	done := doneOf(r)
+	for x := range s.out {
+		select {
+		case r.In() <- x:
+		case <-done:
+			s.cancel()
+		}
+	}
+
+	close(r.In())
+}
+
+func (s *SkipUntilImpl) attach() {
+	signal := s.other.Out()
+	skipping := true
+
+loop:
+	for {
+		select {
+		case x, ok := <-s.in:
+			if !ok {
+				break loop
+			}
+
+			if !skipping {
+				s.out <- x
+			}
+		case _, ok := <-signal:
+			if ok {
+				skipping = false
+				drain(signal)
+			}
+
+			signal = nil // only the first signal matters
+		}
+	}
+
+	drain(signal)
+	close(s.out)
+}
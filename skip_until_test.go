@@ -0,0 +1,29 @@
+package streams_test
+
+import (
+	"testing"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkipUntil(t *testing.T) {
+	signal := make(chan any)
+	recv := streams.SkipUntil(sources.NewChanSource(signal))
+
+	go func() {
+		recv.In() <- 1
+		recv.In() <- 2
+		signal <- struct{}{}
+		recv.In() <- 3
+		signal <- struct{}{} // later signals are drained
+		recv.In() <- 4
+		close(recv.In())
+		close(signal)
+	}()
+
+	output := channels.Slice[int](recv.Out())
+	require.Equal(t, []int{3, 4}, output)
+}
@@ -0,0 +1,88 @@
+package streams
+
+var (
+	_ Streamable = (*SkipWhileImpl[any])(nil)
+	_ Receivable = (*SkipWhileImpl[any])(nil)
+)
+
+// SkipWhileImpl skips elements as long as the predicate holds and passes all elements afterwards.
+type SkipWhileImpl[T any] struct {
+	fn  FilterPredicate[T]
+	in  chan any
+	out chan any
+	canceler
+}
+
+// SkipWhile returns a new operator that skips elements as long as the predicate holds.
+func SkipWhile[T any](fn FilterPredicate[T]) *SkipWhileImpl[T] {
+	return NewSkipWhile(fn)
+}
+
+// NewSkipWhile returns a new operator that skips elements as long as the predicate holds.
+func NewSkipWhile[T any](fn FilterPredicate[T]) *SkipWhileImpl[T] {
+	s := &SkipWhileImpl[T]{
+		fn:       fn,
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
+	}
+
+	go s.attach()
+
+	return s
+}
+
+// To streams data to the sink and waits for it to complete.
+func (s *SkipWhileImpl[T]) To(sink Sinkable) error {
+	s.stream(sink)
+
+	err := sink.Wait()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// In returns the input channel.
+func (s *SkipWhileImpl[T]) In() chan<- any {
+	return s.in
+}
+
+// Out returns the output channel.
+func (s *SkipWhileImpl[T]) Out() <-chan any {
+	return s.out
+}
+
+// Pipe pipes the output channel to the input channel.
+func (s *SkipWhileImpl[T]) Pipe(c Operatable) Operatable {
+	go s.stream(c)
+	return c
+}
+
+func (s *SkipWhileImpl[T]) stream(r Receivable) {
+	done := doneOf(r)
+	for x := range s.out {
+		select {
+		case r.In() <- x:
+		case <-done:
+			s.cancel()
+		}
+	}
+
+	close(r.In())
+}
+
+func (s *SkipWhileImpl[T]) attach() {
+	skipping := true
+	for x := range s.in {
+		if skipping && s.fn(x.(T)) {
+			continue
+		}
+		skipping = false
+
+		s.out <- x
+	}
+
+	close(s.out)
+}
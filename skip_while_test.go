@@ -0,0 +1,53 @@
+package streams_test
+
+import (
+	"testing"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/sinks"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkipWhile(t *testing.T) {
+	tests := []struct {
+		name     string
+		recv     streams.Operatable
+		in       []int
+		expected []int
+	}{
+		{
+			name:     "less than 3",
+			in:       []int{1, 2, 3, 1, 2},
+			expected: []int{3, 1, 2},
+			recv:     streams.SkipWhile(lessThan(3)),
+		},
+		{
+			name:     "all",
+			in:       []int{1, 2, 3},
+			expected: []int{},
+			recv:     streams.SkipWhile(lessThan(10)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := make(chan any, 5)
+			out := make(chan any, 5)
+
+			channels.Channel(tt.in, in)
+
+			source := sources.NewChanSource(in)
+			sink := sinks.NewChanSink(out)
+
+			close(in)
+
+			err := source.Pipe(tt.recv).To(sink)
+			require.NoError(t, err)
+
+			output := channels.Slice[int](out)
+			require.Equal(t, tt.expected, output)
+		})
+	}
+}
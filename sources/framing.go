@@ -0,0 +1,296 @@
+package sources
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DefaultMaxElementSize is the default maximum size of an element in bytes.
+// The built-in framings use it if their maximum size is not positive.
+const DefaultMaxElementSize = 1 << 20
+
+var (
+	// ErrElementTooLarge is returned when an element exceeds the maximum element size.
+	ErrElementTooLarge = errors.New("sources: element exceeds the maximum size")
+	// ErrInvalidJSON is returned when an NDJSON element is not valid JSON.
+	ErrInvalidJSON = errors.New("sources: element is not valid JSON")
+)
+
+// FramingError is an error that occurred while reading an element.
+type FramingError struct {
+	// Offset is the byte offset of the element that could not be read.
+	Offset int64
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the error message.
+func (e *FramingError) Error() string {
+	return fmt.Sprintf("sources: framing error at offset %d: %v", e.Offset, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *FramingError) Unwrap() error {
+	return e.Err
+}
+
+// framer keeps the buffered reader and the offset of an ElementReader between calls.
+// It is bound to the first reader that is read.
+type framer struct {
+	br     *bufio.Reader
+	offset int64
+}
+
+// frame returns a Framing that creates an ElementReader with a new framer.
+func frame(fn func(f *framer, r io.Reader) ([]byte, error)) Framing {
+	return func() ElementReader {
+		f := new(framer)
+
+		return func(r io.Reader) ([]byte, error) {
+			return fn(f, r)
+		}
+	}
+}
+
+func (f *framer) reader(r io.Reader) *bufio.Reader {
+	if f.br == nil {
+		f.br = bufio.NewReader(r)
+	}
+
+	return f.br
+}
+
+func (f *framer) fail(offset int64, err error) error {
+	return &FramingError{Offset: offset, Err: err}
+}
+
+// readUntil reads up to and including the delimiter. The delimiter is not returned.
+func (f *framer) readUntil(r io.Reader, delim byte, maxSize int) ([]byte, error) {
+	br := f.reader(r)
+	start := f.offset
+
+	var element []byte
+	for {
+		chunk, err := br.ReadSlice(delim)
+		f.offset += int64(len(chunk))
+		element = append(element, chunk...)
+
+		if len(element) > maxSize+1 {
+			return nil, f.fail(start, ErrElementTooLarge)
+		}
+
+		switch {
+		case err == nil:
+			return element[:len(element)-1], nil
+		case errors.Is(err, bufio.ErrBufferFull):
+			continue
+		case errors.Is(err, io.EOF):
+			if len(element) > maxSize {
+				return nil, f.fail(start, ErrElementTooLarge)
+			}
+
+			return element, io.EOF
+		default:
+			return nil, f.fail(start, err)
+		}
+	}
+}
+
+func (f *framer) readFull(r io.Reader, size int, start int64) ([]byte, error) {
+	element := make([]byte, size)
+
+	n, err := io.ReadFull(f.reader(r), element)
+	f.offset += int64(n)
+
+	if err != nil {
+		return nil, f.fail(start, err)
+	}
+
+	return element, nil
+}
+
+// Lines returns a Framing for newline-delimited elements.
+// A trailing carriage return is removed from each line.
+func Lines(maxSize int) Framing {
+	maxSize = maxElementSize(maxSize)
+
+	return frame(func(f *framer, r io.Reader) ([]byte, error) {
+		line, err := f.readUntil(r, '\n', maxSize)
+
+		return bytes.TrimSuffix(line, []byte("\r")), err
+	})
+}
+
+// Delimited returns a Framing for elements separated by a delimiter.
+func Delimited(delim byte, maxSize int) Framing {
+	maxSize = maxElementSize(maxSize)
+
+	return frame(func(f *framer, r io.Reader) ([]byte, error) {
+		return f.readUntil(r, delim, maxSize)
+	})
+}
+
+// FixedSize returns a Framing for elements of a fixed size. The size is at least one byte.
+func FixedSize(size int) Framing {
+	size = max(size, 1)
+
+	return frame(func(f *framer, r io.Reader) ([]byte, error) {
+		start := f.offset
+
+		if _, err := f.reader(r).Peek(1); errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+
+		return f.readFull(r, size, start)
+	})
+}
+
+// VarintLengthPrefixed returns a Framing for elements prefixed with their length as unsigned varint.
+func VarintLengthPrefixed(maxSize int) Framing {
+	maxSize = maxElementSize(maxSize)
+
+	return frame(func(f *framer, r io.Reader) ([]byte, error) {
+		br := f.reader(r)
+		start := f.offset
+
+		if _, err := br.Peek(1); errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+
+		cr := &countingByteReader{r: br}
+		size, err := binary.ReadUvarint(cr)
+		f.offset += cr.n
+
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+
+		if err != nil {
+			return nil, f.fail(start, err)
+		}
+
+		if size > uint64(maxSize) {
+			return nil, f.fail(start, ErrElementTooLarge)
+		}
+
+		return f.readFull(r, int(size), start)
+	})
+}
+
+// LengthPrefixed returns a Framing for elements prefixed with their length as 4-byte big-endian integer.
+func LengthPrefixed(maxSize int) Framing {
+	maxSize = maxElementSize(maxSize)
+
+	return frame(func(f *framer, r io.Reader) ([]byte, error) {
+		start := f.offset
+
+		if _, err := f.reader(r).Peek(1); errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+
+		prefix, err := f.readFull(r, 4, start)
+		if err != nil {
+			return nil, err
+		}
+
+		size := binary.BigEndian.Uint32(prefix)
+		if uint64(size) > uint64(maxSize) {
+			return nil, f.fail(start, ErrElementTooLarge)
+		}
+
+		return f.readFull(r, int(size), start)
+	})
+}
+
+// NDJSON returns a Framing for newline-delimited JSON with one JSON value per element.
+// Blank lines are skipped.
+func NDJSON(maxSize int) Framing {
+	maxSize = maxElementSize(maxSize)
+
+	return frame(func(f *framer, r io.Reader) ([]byte, error) {
+		for {
+			start := f.offset
+
+			line, err := f.readUntil(r, '\n', maxSize)
+			if err != nil && !errors.Is(err, io.EOF) {
+				return nil, err
+			}
+
+			line = bytes.TrimSpace(line)
+			if len(line) == 0 && err == nil {
+				continue
+			}
+
+			if len(line) > 0 && !json.Valid(line) {
+				return nil, f.fail(start, ErrInvalidJSON)
+			}
+
+			return line, err
+		}
+	})
+}
+
+// CSV returns a Framing for RFC 4180 CSV records.
+// Each element is the raw record without the line break, quoted fields may contain line breaks.
+func CSV(maxSize int) Framing {
+	maxSize = maxElementSize(maxSize)
+
+	return frame(func(f *framer, r io.Reader) ([]byte, error) {
+		start := f.offset
+
+		var record []byte
+		for {
+			line, err := f.readUntil(r, '\n', maxSize-len(record))
+			if err != nil && !errors.Is(err, io.EOF) {
+				var ferr *FramingError
+				if errors.As(err, &ferr) {
+					ferr.Offset = start
+				}
+
+				return nil, err
+			}
+
+			record = append(record, line...)
+
+			// a record ends at a line break outside of quotes
+			quoted := bytes.Count(record, []byte(`"`))%2 != 0
+			if quoted && err != nil {
+				return nil, f.fail(start, io.ErrUnexpectedEOF)
+			}
+
+			if !quoted {
+				return bytes.TrimSuffix(record, []byte("\r")), err
+			}
+
+			record = append(record, '\n')
+		}
+	})
+}
+
+// maxElementSize returns the maximum size or DefaultMaxElementSize if it is not positive.
+func maxElementSize(size int) int {
+	if size <= 0 {
+		return DefaultMaxElementSize
+	}
+
+	return size
+}
+
+type countingByteReader struct {
+	r *bufio.Reader
+	n int64
+}
+
+func (c *countingByteReader) ReadByte() (byte, error) {
+	b, err := c.r.ReadByte()
+	if err == nil {
+		c.n++
+	}
+
+	return b, err
+}
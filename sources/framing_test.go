@@ -0,0 +1,178 @@
+package sources_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func varint(s string) string {
+	return string(binary.AppendUvarint(nil, uint64(len(s)))) + s
+}
+
+func uint32BE(s string) string {
+	return string(binary.BigEndian.AppendUint32(nil, uint32(len(s)))) + s
+}
+
+func TestElementReaders(t *testing.T) {
+	tests := []struct {
+		name     string
+		framing  sources.Framing
+		in       string
+		expected []string
+		offset   int64
+		err      error
+	}{
+		{
+			name:     "lines",
+			framing:  sources.Lines(sources.DefaultMaxElementSize),
+			in:       "foo\r\nbar\nbaz",
+			expected: []string{"foo", "bar", "baz"},
+		},
+		{
+			name:     "lines too large",
+			framing:  sources.Lines(3),
+			in:       "foo\nbarbaz\n",
+			expected: []string{"foo"},
+			offset:   4,
+			err:      sources.ErrElementTooLarge,
+		},
+		{
+			name:     "lines default size",
+			framing:  sources.Lines(0),
+			in:       "foo\nbar",
+			expected: []string{"foo", "bar"},
+		},
+		{
+			name:     "delimited",
+			framing:  sources.Delimited(0, sources.DefaultMaxElementSize),
+			in:       "foo\x00bar\x00",
+			expected: []string{"foo", "bar"},
+		},
+		{
+			name:     "fixed size",
+			framing:  sources.FixedSize(3),
+			in:       "foobarba",
+			expected: []string{"foo", "bar"},
+			offset:   6,
+			err:      io.ErrUnexpectedEOF,
+		},
+		{
+			name:     "fixed size at least one byte",
+			framing:  sources.FixedSize(0),
+			in:       "ab",
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "varint length prefixed",
+			framing:  sources.VarintLengthPrefixed(sources.DefaultMaxElementSize),
+			in:       varint("foo") + varint(strings.Repeat("x", 200)),
+			expected: []string{"foo", strings.Repeat("x", 200)},
+		},
+		{
+			name:     "varint length prefixed too large",
+			framing:  sources.VarintLengthPrefixed(3),
+			in:       varint("foo") + varint("barbaz"),
+			expected: []string{"foo"},
+			offset:   4,
+			err:      sources.ErrElementTooLarge,
+		},
+		{
+			name:     "length prefixed",
+			framing:  sources.LengthPrefixed(sources.DefaultMaxElementSize),
+			in:       uint32BE("foo") + uint32BE("bar"),
+			expected: []string{"foo", "bar"},
+		},
+		{
+			name:     "length prefixed truncated",
+			framing:  sources.LengthPrefixed(sources.DefaultMaxElementSize),
+			in:       uint32BE("foo") + uint32BE("bar")[:5],
+			expected: []string{"foo"},
+			offset:   7,
+			err:      io.ErrUnexpectedEOF,
+		},
+		{
+			name:     "ndjson",
+			framing:  sources.NDJSON(sources.DefaultMaxElementSize),
+			in:       "{\"a\":1}\n\n{\"b\":2}\n",
+			expected: []string{`{"a":1}`, `{"b":2}`},
+		},
+		{
+			name:     "ndjson invalid",
+			framing:  sources.NDJSON(sources.DefaultMaxElementSize),
+			in:       "{\"a\":1}\n{\"b\":\n",
+			expected: []string{`{"a":1}`},
+			offset:   8,
+			err:      sources.ErrInvalidJSON,
+		},
+		{
+			name:     "csv",
+			framing:  sources.CSV(sources.DefaultMaxElementSize),
+			in:       "a,b\r\n\"multi\nline\",\"quoted \"\"c\"\"\"\r\nd,e",
+			expected: []string{"a,b", "\"multi\nline\",\"quoted \"\"c\"\"\"", "d,e"},
+		},
+		{
+			name:     "csv unterminated quote",
+			framing:  sources.CSV(sources.DefaultMaxElementSize),
+			in:       "a,b\n\"c,d\n",
+			expected: []string{"a,b"},
+			offset:   4,
+			err:      io.ErrUnexpectedEOF,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := sources.NewReaderSource(io.NopCloser(strings.NewReader(tt.in)), tt.framing())
+
+			output := make([]string, 0)
+			for _, b := range channels.Slice[[]byte](source.Out()) {
+				output = append(output, string(b))
+			}
+
+			assert.Equal(t, tt.expected, output)
+
+			if tt.err == nil {
+				require.NoError(t, source.Error())
+				return
+			}
+
+			require.ErrorIs(t, source.Error(), tt.err)
+
+			var ferr *sources.FramingError
+			require.ErrorAs(t, source.Error(), &ferr)
+			assert.Equal(t, tt.offset, ferr.Offset)
+		})
+	}
+}
+
+func TestReaderSourceOffset(t *testing.T) {
+	errRead := errors.New("read")
+	calls := 0
+
+	source := sources.NewReaderSource(io.NopCloser(strings.NewReader("foobar")), func(r io.Reader) ([]byte, error) {
+		calls++
+		if calls > 2 {
+			return nil, errRead
+		}
+
+		b := make([]byte, 2)
+		_, err := io.ReadFull(r, b)
+
+		return b, err
+	})
+
+	assert.Len(t, channels.Slice[[]byte](source.Out()), 2)
+
+	var ferr *sources.FramingError
+	require.ErrorAs(t, source.Error(), &ferr)
+	require.ErrorIs(t, ferr, errRead)
+	assert.Equal(t, int64(4), ferr.Offset)
+}
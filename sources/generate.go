@@ -0,0 +1,201 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/katallaxie/streams"
+)
+
+var _ streams.Sourceable = (*FuncSource[any])(nil)
+
+// ErrInvalidStep is returned if the step of a range is zero.
+var ErrInvalidStep = errors.New("sources: step must not be zero")
+
+// Number is a constraint for the numeric types.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// FuncSource is a source that emits the elements of a function until it returns io.EOF.
+// Any other error fails the source, the source stops if the context is canceled.
+type FuncSource[T any] struct {
+	fn      func(context.Context) (T, error)
+	stop    func()
+	out     chan any
+	err     error
+	errOnce sync.Once
+}
+
+// FromFunc returns a new source that polls the function until it returns io.EOF.
+func FromFunc[T any](ctx context.Context, fn func(context.Context) (T, error)) *FuncSource[T] {
+	return NewFuncSource(ctx, fn)
+}
+
+// NewFuncSource returns a new source that polls the function until it returns io.EOF.
+func NewFuncSource[T any](ctx context.Context, fn func(context.Context) (T, error)) *FuncSource[T] {
+	return newFuncSource(ctx, fn, nil)
+}
+
+func newFuncSource[T any](ctx context.Context, fn func(context.Context) (T, error), stop func()) *FuncSource[T] {
+	s := &FuncSource[T]{
+		fn:   fn,
+		stop: stop,
+		out:  make(chan any),
+	}
+
+	go s.attach(ctx)
+
+	return s
+}
+
+// Error returns the error.
+func (s *FuncSource[T]) Error() error {
+	return s.err
+}
+
+func (s *FuncSource[T]) fail(err error) {
+	s.errOnce.Do(func() {
+		s.err = err
+	})
+}
+
+// Pipe pipes the output channel to the input channel.
+func (s *FuncSource[T]) Pipe(operator streams.Operatable) streams.Operatable {
+	streams.Pipe(s, operator)
+	return operator
+}
+
+// Out returns the output channel.
+func (s *FuncSource[T]) Out() <-chan any {
+	return s.out
+}
+
+func (s *FuncSource[T]) attach(ctx context.Context) {
+	defer close(s.out)
+
+	if s.stop != nil {
+		defer s.stop()
+	}
+
+	for ctx.Err() == nil {
+		x, err := s.fn(ctx)
+		if errors.Is(err, io.EOF) {
+			return
+		}
+
+		if err != nil {
+			if ctx.Err() == nil {
+				s.fail(err)
+			}
+
+			return
+		}
+
+		select {
+		case s.out <- x:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Range returns a new source that emits the numbers from start up to, but not including, end.
+// A negative step counts down.
+func Range[T Number](ctx context.Context, start, end, step T) (*FuncSource[T], error) {
+	if step == 0 {
+		return nil, ErrInvalidStep
+	}
+
+	descending := step < 0
+	next := start
+
+	return FromFunc(ctx, func(context.Context) (T, error) {
+		if (!descending && next >= end) || (descending && next <= end) {
+			return 0, io.EOF
+		}
+
+		x := next
+		next += step
+
+		// stop if the number overflowed
+		if (!descending && next < x) || (descending && next > x) {
+			next = end
+		}
+
+		return x, nil
+	}), nil
+}
+
+// Ticker returns a new source that emits the time of each tick of the interval.
+// The ticker is stopped if the context is canceled.
+func Ticker(ctx context.Context, interval time.Duration) *FuncSource[time.Time] {
+	ticker := time.NewTicker(interval)
+
+	return newFuncSource(ctx, func(ctx context.Context) (time.Time, error) {
+		select {
+		case t := <-ticker.C:
+			return t, nil
+		case <-ctx.Done():
+			return time.Time{}, ctx.Err()
+		}
+	}, ticker.Stop)
+}
+
+// Interval returns a new source that emits 0, 1, 2 and so on, one number for each interval.
+func Interval(ctx context.Context, interval time.Duration) *FuncSource[int] {
+	ticker := time.NewTicker(interval)
+	n := 0
+
+	return newFuncSource(ctx, func(ctx context.Context) (int, error) {
+		select {
+		case <-ticker.C:
+			n++
+			return n - 1, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}, ticker.Stop)
+}
+
+// Timer returns a new source that emits the time once after the duration.
+func Timer(ctx context.Context, d time.Duration) *FuncSource[time.Time] {
+	timer := time.NewTimer(d)
+	fired := false
+
+	return newFuncSource(ctx, func(ctx context.Context) (time.Time, error) {
+		if fired {
+			return time.Time{}, io.EOF
+		}
+
+		select {
+		case t := <-timer.C:
+			fired = true
+			return t, nil
+		case <-ctx.Done():
+			return time.Time{}, ctx.Err()
+		}
+	}, func() { timer.Stop() })
+}
+
+// Unfold returns a new source that emits the elements generated from a seed.
+// The function returns the element and the next state, the source ends if it returns false.
+func Unfold[S, T any](ctx context.Context, seed S, fn func(S) (T, S, bool)) *FuncSource[T] {
+	state := seed
+
+	return FromFunc(ctx, func(context.Context) (T, error) {
+		x, next, ok := fn(state)
+		if !ok {
+			var zero T
+			return zero, io.EOF
+		}
+		state = next
+
+		return x, nil
+	})
+}
@@ -0,0 +1,101 @@
+package sources_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRange(t *testing.T) {
+	tests := []struct {
+		name             string
+		start, end, step int
+		expected         []any
+	}{
+		{name: "ascending", start: 0, end: 5, step: 2, expected: []any{0, 2, 4}},
+		{name: "descending", start: 3, end: 0, step: -1, expected: []any{3, 2, 1}},
+		{name: "empty", start: 3, end: 0, step: 1, expected: []any{}},
+		{name: "overflow", start: math.MaxInt - 1, end: math.MaxInt, step: 2, expected: []any{math.MaxInt - 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, err := sources.Range(context.Background(), tt.start, tt.end, tt.step)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expected, append([]any{}, channels.Slice[any](src.Out())...))
+			require.NoError(t, src.Error())
+		})
+	}
+
+	_, err := sources.Range(context.Background(), 0, 1, 0)
+	require.ErrorIs(t, err, sources.ErrInvalidStep)
+}
+
+func TestTicker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	src := sources.Ticker(ctx, time.Millisecond)
+	require.IsType(t, time.Time{}, receive(t, src.Out()))
+	require.IsType(t, time.Time{}, receive(t, src.Out()))
+
+	cancel()
+	channels.Drain(src.Out())
+	require.NoError(t, src.Error())
+}
+
+func TestInterval(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	src := sources.Interval(ctx, time.Millisecond)
+	require.Equal(t, 0, receive(t, src.Out()))
+	require.Equal(t, 1, receive(t, src.Out()))
+}
+
+func TestTimer(t *testing.T) {
+	src := sources.Timer(context.Background(), time.Millisecond)
+
+	elements := channels.Slice[any](src.Out())
+	require.Len(t, elements, 1)
+	require.NoError(t, src.Error())
+}
+
+func TestFromFunc(t *testing.T) {
+	n := 0
+	src := sources.FromFunc(context.Background(), func(context.Context) (int, error) {
+		n++
+		if n > 3 {
+			return 0, io.EOF
+		}
+
+		return n, nil
+	})
+
+	require.Equal(t, []any{1, 2, 3}, channels.Slice[any](src.Out()))
+	require.NoError(t, src.Error())
+
+	errFailed := errors.New("failed")
+	src = sources.FromFunc(context.Background(), func(context.Context) (int, error) {
+		return 0, errFailed
+	})
+
+	require.Empty(t, channels.Slice[any](src.Out()))
+	require.ErrorIs(t, src.Error(), errFailed)
+}
+
+func TestUnfold(t *testing.T) {
+	fib := sources.Unfold(context.Background(), [2]int{0, 1}, func(s [2]int) (int, [2]int, bool) {
+		return s[0], [2]int{s[1], s[0] + s[1]}, s[0] < 10
+	})
+
+	require.Equal(t, []any{0, 1, 1, 2, 3, 5, 8}, channels.Slice[any](fib.Out()))
+	require.NoError(t, fib.Error())
+}
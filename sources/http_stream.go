@@ -0,0 +1,349 @@
+package sources
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/katallaxie/streams"
+)
+
+var _ streams.Sourceable = (*HTTPStreamSource)(nil)
+
+// HTTPStreamFormat is the format of an HTTP stream.
+type HTTPStreamFormat int
+
+const (
+	// DetectFormat detects the format by the content type of the response.
+	DetectFormat HTTPStreamFormat = iota
+	// SSEFormat parses the response as Server-Sent Events.
+	SSEFormat
+	// NDJSONFormat parses the response as newline delimited JSON.
+	NDJSONFormat
+)
+
+// ServerSentEvent is emitted for each event of a Server-Sent Events stream.
+type ServerSentEvent struct {
+	// ID is the last event ID.
+	ID string
+	// Event is the event type, it defaults to "message".
+	Event string
+	// Data is the data of the event.
+	Data []byte
+}
+
+// HTTPStatusError is returned for an unexpected status code.
+type HTTPStatusError struct {
+	// StatusCode is the status code of the response.
+	StatusCode int
+}
+
+// Error returns the error message.
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("sources: unexpected status %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Temporary returns true if the request can be retried.
+func (e *HTTPStatusError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// HTTPStreamOptions holds the options for an HTTP stream source.
+type HTTPStreamOptions struct {
+	// Client is the HTTP client.
+	Client *http.Client
+	// Header is added to each request.
+	Header http.Header
+	// Format is the format of the stream.
+	Format HTTPStreamFormat
+	// Reconnect is the policy to reconnect to the stream.
+	// MaxAttempts is the number of consecutive failed connections, it is unlimited if zero.
+	Reconnect *streams.RetryPolicy
+	// MaxElementSize is the maximum size of a line in bytes.
+	MaxElementSize int
+}
+
+// DefaultHTTPStreamOptions returns default HTTP stream options.
+func DefaultHTTPStreamOptions() *HTTPStreamOptions {
+	return &HTTPStreamOptions{
+		Client: http.DefaultClient,
+		Reconnect: &streams.RetryPolicy{
+			InitialBackoff: time.Second,
+			MaxBackoff:     30 * time.Second,
+			Multiplier:     2,
+			Jitter:         0.2,
+		},
+		MaxElementSize: DefaultMaxElementSize,
+	}
+}
+
+// errStreamEnd signals that the server does not want the client to reconnect.
+var errStreamEnd = errors.New("sources: end of stream")
+
+// HTTPStreamSource is a source that reads Server-Sent Events or NDJSON from an HTTP stream.
+// It reconnects with backoff and resumes Server-Sent Events with Last-Event-ID.
+// A response with 204 No Content ends the stream.
+type HTTPStreamSource struct {
+	url         string
+	opts        *HTTPStreamOptions
+	out         chan any
+	err         error
+	errOnce     sync.Once
+	lastEventID string
+	retry       time.Duration
+}
+
+// HTTPStream returns a new source that reads from an HTTP stream.
+func HTTPStream(ctx context.Context, url string, opts *HTTPStreamOptions) (*HTTPStreamSource, error) {
+	return NewHTTPStreamSource(ctx, url, opts)
+}
+
+// NewHTTPStreamSource returns a new source that reads from an HTTP stream.
+// Zero values of the options are replaced by the defaults.
+func NewHTTPStreamSource(ctx context.Context, url string, opts *HTTPStreamOptions) (*HTTPStreamSource, error) {
+	defaults := DefaultHTTPStreamOptions()
+	if opts == nil {
+		opts = defaults
+	}
+
+	o := *opts
+	if o.Client == nil {
+		o.Client = defaults.Client
+	}
+
+	if o.Reconnect == nil {
+		o.Reconnect = defaults.Reconnect
+	}
+
+	if o.MaxElementSize <= 0 {
+		o.MaxElementSize = defaults.MaxElementSize
+	}
+
+	s := &HTTPStreamSource{
+		url:  url,
+		opts: &o,
+		out:  make(chan any),
+	}
+
+	go s.attach(ctx)
+
+	return s, nil
+}
+
+// Error returns the error.
+func (s *HTTPStreamSource) Error() error {
+	return s.err
+}
+
+func (s *HTTPStreamSource) fail(err error) {
+	s.errOnce.Do(func() {
+		s.err = err
+	})
+}
+
+// Pipe pipes the output channel to the input channel.
+func (s *HTTPStreamSource) Pipe(operator streams.Operatable) streams.Operatable {
+	streams.Pipe(s, operator)
+	return operator
+}
+
+// Out returns the output channel.
+func (s *HTTPStreamSource) Out() <-chan any {
+	return s.out
+}
+
+func (s *HTTPStreamSource) attach(ctx context.Context) {
+	defer close(s.out)
+
+	policy := s.opts.Reconnect
+	attempt := 0
+
+	for {
+		received, err := s.connect(ctx)
+		if ctx.Err() != nil || errors.Is(err, errStreamEnd) {
+			return
+		}
+
+		if received {
+			attempt = 0
+		}
+
+		if err != nil {
+			attempt++
+
+			if !s.retryable(err) || (policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts) {
+				s.fail(&streams.RetryError{Attempts: attempt, Err: err})
+				return
+			}
+		}
+
+		wait := s.retry
+		if wait == 0 {
+			wait = policy.Backoff(max(attempt, 1))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (s *HTTPStreamSource) retryable(err error) bool {
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) && !statusErr.Temporary() {
+		return false
+	}
+
+	return s.opts.Reconnect.Retryable == nil || s.opts.Reconnect.Retryable(err)
+}
+
+// connect reads the stream until it ends and returns if any elements have been received.
+func (s *HTTPStreamSource) connect(ctx context.Context) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return false, err
+	}
+
+	for k, v := range s.opts.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Accept", "text/event-stream, application/x-ndjson")
+	req.Header.Set("Cache-Control", "no-cache")
+
+	if s.lastEventID != "" {
+		req.Header.Set("Last-Event-ID", s.lastEventID)
+	}
+
+	res, err := s.opts.Client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNoContent {
+		return false, errStreamEnd
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return false, &HTTPStatusError{StatusCode: res.StatusCode}
+	}
+
+	scanner := bufio.NewScanner(res.Body)
+	scanner.Buffer(make([]byte, 0, 4096), s.opts.MaxElementSize)
+
+	format := s.opts.Format
+	if format == DetectFormat {
+		format = NDJSONFormat
+		if mediaType, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type")); mediaType == "text/event-stream" {
+			format = SSEFormat
+		}
+	}
+
+	if format == SSEFormat {
+		return s.readEvents(ctx, scanner)
+	}
+
+	return s.readLines(ctx, scanner)
+}
+
+func (s *HTTPStreamSource) readLines(ctx context.Context, scanner *bufio.Scanner) (bool, error) {
+	received := false
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		if err := s.emit(ctx, bytes.Clone(line)); err != nil {
+			return received, err
+		}
+		received = true
+	}
+
+	return received, scanErr(scanner.Err())
+}
+
+func (s *HTTPStreamSource) readEvents(ctx context.Context, scanner *bufio.Scanner) (bool, error) {
+	received := false
+
+	var event string
+	var data []byte
+	hasData := false
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line == "" {
+			if hasData {
+				if event == "" {
+					event = "message"
+				}
+
+				if err := s.emit(ctx, ServerSentEvent{ID: s.lastEventID, Event: event, Data: data}); err != nil {
+					return received, err
+				}
+				received = true
+			}
+
+			event, data, hasData = "", nil, false
+
+			continue
+		}
+
+		if strings.HasPrefix(line, ":") {
+			continue // comment
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			if hasData {
+				data = append(data, '\n')
+			}
+			data = append(data, value...)
+			hasData = true
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				s.lastEventID = value
+			}
+		case "retry":
+			if ms, err := strconv.ParseUint(value, 10, 63); err == nil {
+				s.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+
+	return received, scanErr(scanner.Err())
+}
+
+func (s *HTTPStreamSource) emit(ctx context.Context, x any) error {
+	select {
+	case s.out <- x:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func scanErr(err error) error {
+	if errors.Is(err, bufio.ErrTooLong) {
+		return ErrElementTooLarge
+	}
+
+	return err
+}
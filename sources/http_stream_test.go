@@ -0,0 +1,112 @@
+package sources_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/require"
+)
+
+func httpStreamOptions() *sources.HTTPStreamOptions {
+	opts := sources.DefaultHTTPStreamOptions()
+	opts.Reconnect.InitialBackoff = time.Millisecond
+
+	return opts
+}
+
+func TestHTTPStream_SSE(t *testing.T) {
+	var conns atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch conns.Add(1) {
+		case 1:
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, ": comment\n\nid: 1\ndata: foo\ndata: bar\n\nid: 2\nevent: update\ndata: baz\n\n")
+		case 2:
+			if r.Header.Get("Last-Event-ID") != "2" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 3:
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "retry: 1\ndata: qux\n\n")
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	src, err := sources.HTTPStream(context.Background(), srv.URL, httpStreamOptions())
+	require.NoError(t, err)
+
+	events := channels.Slice[any](src.Out())
+	require.NoError(t, src.Error())
+	require.Equal(t, []any{
+		sources.ServerSentEvent{ID: "1", Event: "message", Data: []byte("foo\nbar")},
+		sources.ServerSentEvent{ID: "2", Event: "update", Data: []byte("baz")},
+		sources.ServerSentEvent{ID: "2", Event: "message", Data: []byte("qux")},
+	}, events)
+}
+
+func TestHTTPStream_NDJSON(t *testing.T) {
+	var conns atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if conns.Add(1) > 2 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		fmt.Fprint(w, "{\"a\":1}\n\n{\"b\":2}\n")
+	}))
+	defer srv.Close()
+
+	src, err := sources.HTTPStream(context.Background(), srv.URL, httpStreamOptions())
+	require.NoError(t, err)
+
+	elements := channels.Slice[any](src.Out())
+	require.NoError(t, src.Error())
+	require.Equal(t, []any{[]byte(`{"a":1}`), []byte(`{"b":2}`), []byte(`{"a":1}`), []byte(`{"b":2}`)}, elements)
+}
+
+func TestHTTPStream_Error(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	src, err := sources.HTTPStream(context.Background(), srv.URL, httpStreamOptions())
+	require.NoError(t, err)
+
+	require.Empty(t, channels.Slice[any](src.Out()))
+
+	var statusErr *sources.HTTPStatusError
+	require.ErrorAs(t, src.Error(), &statusErr)
+	require.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+
+	var retryErr *streams.RetryError
+	require.ErrorAs(t, src.Error(), &retryErr)
+	require.Equal(t, 1, retryErr.Attempts)
+}
+
+func TestHTTPStream_ZeroOptions(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	src, err := sources.HTTPStream(context.Background(), srv.URL, &sources.HTTPStreamOptions{})
+	require.NoError(t, err)
+
+	require.Empty(t, channels.Slice[any](src.Out()))
+
+	var statusErr *sources.HTTPStatusError
+	require.ErrorAs(t, src.Error(), &statusErr)
+	require.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+}
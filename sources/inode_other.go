@@ -0,0 +1,10 @@
+//go:build !unix
+
+package sources
+
+import "io/fs"
+
+// inode returns zero on platforms without inode numbers.
+func inode(fs.FileInfo) uint64 {
+	return 0
+}
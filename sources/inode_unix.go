@@ -0,0 +1,17 @@
+//go:build unix
+
+package sources
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// inode returns the inode number of a file.
+func inode(info fs.FileInfo) uint64 {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return uint64(stat.Ino)
+	}
+
+	return 0
+}
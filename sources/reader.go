@@ -5,16 +5,21 @@ import (
 	"io"
 	"sync"
 
-	"github.com/katallaxie/pkg/slices"
 	"github.com/katallaxie/streams"
 )
 
 // ElementReader is a function that reads an element from an io.Reader.
 type ElementReader func(io.Reader) ([]byte, error)
 
+// Framing returns a new ElementReader for each reader, e.g. a file, request or connection,
+// because the element readers of the built-in framings keep the state of a single reader.
+type Framing func() ElementReader
+
 // ReaderSource is a source connector that reads elements from an io.Reader.
+// Empty elements are skipped. Errors of the ElementReader are reported as FramingError with the byte offset.
 type ReaderSource struct {
 	reader        io.ReadCloser
+	counter       *countingReader
 	elementReader ElementReader
 	out           chan any
 	err           error
@@ -27,6 +32,7 @@ var _ streams.Sourceable = (*ReaderSource)(nil)
 func NewReaderSource(reader io.ReadCloser, elementReader ElementReader) *ReaderSource {
 	readerSource := &ReaderSource{
 		reader:        reader,
+		counter:       &countingReader{r: reader},
 		elementReader: elementReader,
 		out:           make(chan any),
 	}
@@ -50,13 +56,18 @@ func (s *ReaderSource) fail(err error) {
 func (s *ReaderSource) attach() {
 loop:
 	for {
-		b, err := s.elementReader(s.reader)
+		b, err := s.elementReader(s.counter)
 		if errors.Is(err, io.EOF) {
 			s.emitElement(b)
 			break loop
 		}
 
 		if err != nil {
+			var ferr *FramingError
+			if !errors.As(err, &ferr) {
+				err = &FramingError{Offset: s.counter.n, Err: err}
+			}
+
 			s.fail(err)
 			break loop
 		}
@@ -68,10 +79,9 @@ loop:
 	close(s.out)
 }
 
-// emitElement sends the element downstream to the output channel if the context
-// is not canceled and the element is not empty.
+// emitElement sends the element downstream to the output channel if the element is not empty.
 func (s *ReaderSource) emitElement(element []byte) {
-	if slices.GreaterThen(0, element) {
+	if len(element) > 0 {
 		s.out <- element
 	}
 }
@@ -86,3 +96,16 @@ func (s *ReaderSource) Pipe(operator streams.Operatable) streams.Operatable {
 func (s *ReaderSource) Out() <-chan any {
 	return s.out
 }
+
+// countingReader counts the bytes read from a reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+
+	return n, err
+}
@@ -0,0 +1,321 @@
+package sources
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/katallaxie/streams"
+)
+
+var (
+	_ streams.Sourceable = (*ListenerSource)(nil)
+	_ streams.Sourceable = (*PacketSource)(nil)
+)
+
+// SocketElement is an element tagged with the address of the remote peer.
+type SocketElement struct {
+	// RemoteAddr is the address of the remote peer.
+	RemoteAddr net.Addr
+	// Data is the element.
+	Data []byte
+}
+
+// ListenerConfig holds the configuration for a listener source.
+type ListenerConfig struct {
+	// Framing splits the stream of each connection into elements.
+	Framing Framing
+	// TagRemoteAddr emits the elements as SocketElement.
+	TagRemoteAddr bool
+	// OnError is called with the error of a connection that fails to be framed, e.g. a FramingError.
+	OnError func(remoteAddr net.Addr, err error)
+}
+
+// DefaultListenerConfig returns a default listener configuration that reads lines.
+func DefaultListenerConfig() *ListenerConfig {
+	return &ListenerConfig{
+		Framing: Lines(DefaultMaxElementSize),
+	}
+}
+
+// ListenerSource is a source that accepts connections and emits the framed elements of each connection.
+// A connection that fails to be framed is closed, the source keeps accepting new connections.
+type ListenerSource struct {
+	ln      net.Listener
+	cfg     *ListenerConfig
+	out     chan any
+	err     error
+	errOnce sync.Once
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	conns   map[net.Conn]struct{}
+}
+
+// TCP returns a new source that listens for TCP connections on the address.
+func TCP(ctx context.Context, addr string, cfg *ListenerConfig) (*ListenerSource, error) {
+	return Listen(ctx, "tcp", addr, cfg)
+}
+
+// Listen returns a new source that listens for connections on the network address, e.g. "tcp" or "unix".
+func Listen(ctx context.Context, network, addr string, cfg *ListenerConfig) (*ListenerSource, error) {
+	ln, err := (&net.ListenConfig{}).Listen(ctx, network, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewListenerSource(ctx, ln, cfg), nil
+}
+
+// NewListenerSource returns a new source that accepts connections from the listener.
+// The listener is closed if the context is canceled. A nil framing is replaced by the default.
+func NewListenerSource(ctx context.Context, ln net.Listener, cfg *ListenerConfig) *ListenerSource {
+	defaults := DefaultListenerConfig()
+	if cfg == nil {
+		cfg = defaults
+	}
+
+	c := *cfg
+	if c.Framing == nil {
+		c.Framing = defaults.Framing
+	}
+
+	s := &ListenerSource{
+		ln:    ln,
+		cfg:   &c,
+		out:   make(chan any),
+		conns: make(map[net.Conn]struct{}),
+	}
+
+	go s.attach(ctx)
+
+	return s
+}
+
+// Addr returns the address of the listener.
+func (s *ListenerSource) Addr() net.Addr {
+	return s.ln.Addr()
+}
+
+// Error returns the error.
+func (s *ListenerSource) Error() error {
+	return s.err
+}
+
+func (s *ListenerSource) fail(err error) {
+	s.errOnce.Do(func() {
+		s.err = err
+	})
+}
+
+// Pipe pipes the output channel to the input channel.
+func (s *ListenerSource) Pipe(operator streams.Operatable) streams.Operatable {
+	streams.Pipe(s, operator)
+	return operator
+}
+
+// Out returns the output channel.
+func (s *ListenerSource) Out() <-chan any {
+	return s.out
+}
+
+func (s *ListenerSource) attach(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stop := context.AfterFunc(ctx, func() {
+		_ = s.ln.Close()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		for conn := range s.conns {
+			_ = conn.Close()
+		}
+	})
+	defer stop()
+
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			if ctx.Err() == nil && !errors.Is(err, net.ErrClosed) {
+				s.fail(err)
+			}
+
+			break
+		}
+
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go s.serve(ctx, conn)
+	}
+
+	cancel()
+	s.wg.Wait()
+	close(s.out)
+}
+
+func (s *ListenerSource) serve(ctx context.Context, conn net.Conn) {
+	defer s.wg.Done()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+
+		_ = conn.Close()
+	}()
+
+	reader := s.cfg.Framing()
+	for {
+		b, err := reader(conn)
+		if len(b) > 0 {
+			var x any = bytes.Clone(b)
+			if s.cfg.TagRemoteAddr {
+				x = SocketElement{RemoteAddr: conn.RemoteAddr(), Data: x.([]byte)}
+			}
+
+			select {
+			case s.out <- x:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if err != nil {
+			if s.cfg.OnError != nil && ctx.Err() == nil && !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				s.cfg.OnError(conn.RemoteAddr(), err)
+			}
+
+			return
+		}
+	}
+}
+
+// PacketConfig holds the configuration for a packet source.
+type PacketConfig struct {
+	// MaxDatagramSize is the maximum size of a datagram in bytes, larger datagrams are truncated.
+	MaxDatagramSize int
+	// TagRemoteAddr emits the elements as SocketElement.
+	TagRemoteAddr bool
+}
+
+// DefaultPacketConfig returns a default packet configuration.
+func DefaultPacketConfig() *PacketConfig {
+	return &PacketConfig{
+		MaxDatagramSize: 65535,
+	}
+}
+
+// PacketSource is a source that emits each received datagram as an element.
+type PacketSource struct {
+	conn    net.PacketConn
+	cfg     *PacketConfig
+	out     chan any
+	err     error
+	errOnce sync.Once
+}
+
+// UDP returns a new source that receives UDP datagrams on the address.
+func UDP(ctx context.Context, addr string, cfg *PacketConfig) (*PacketSource, error) {
+	return ListenPacket(ctx, "udp", addr, cfg)
+}
+
+// ListenPacket returns a new source that receives datagrams on the network address, e.g. "udp" or "unixgram".
+func ListenPacket(ctx context.Context, network, addr string, cfg *PacketConfig) (*PacketSource, error) {
+	conn, err := (&net.ListenConfig{}).ListenPacket(ctx, network, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPacketSource(ctx, conn, cfg), nil
+}
+
+// NewPacketSource returns a new source that receives datagrams from the connection.
+// The connection is closed if the context is canceled. A non-positive datagram size is replaced by the default.
+func NewPacketSource(ctx context.Context, conn net.PacketConn, cfg *PacketConfig) *PacketSource {
+	defaults := DefaultPacketConfig()
+	if cfg == nil {
+		cfg = defaults
+	}
+
+	c := *cfg
+	if c.MaxDatagramSize <= 0 {
+		c.MaxDatagramSize = defaults.MaxDatagramSize
+	}
+
+	s := &PacketSource{
+		conn: conn,
+		cfg:  &c,
+		out:  make(chan any),
+	}
+
+	go s.attach(ctx)
+
+	return s
+}
+
+// Addr returns the local address of the connection.
+func (s *PacketSource) Addr() net.Addr {
+	return s.conn.LocalAddr()
+}
+
+// Error returns the error.
+func (s *PacketSource) Error() error {
+	return s.err
+}
+
+func (s *PacketSource) fail(err error) {
+	s.errOnce.Do(func() {
+		s.err = err
+	})
+}
+
+// Pipe pipes the output channel to the input channel.
+func (s *PacketSource) Pipe(operator streams.Operatable) streams.Operatable {
+	streams.Pipe(s, operator)
+	return operator
+}
+
+// Out returns the output channel.
+func (s *PacketSource) Out() <-chan any {
+	return s.out
+}
+
+func (s *PacketSource) attach(ctx context.Context) {
+	defer close(s.out)
+	defer s.conn.Close()
+
+	stop := context.AfterFunc(ctx, func() {
+		_ = s.conn.Close()
+	})
+	defer stop()
+
+	buf := make([]byte, s.cfg.MaxDatagramSize)
+	for {
+		n, addr, err := s.conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() == nil && !errors.Is(err, net.ErrClosed) {
+				s.fail(err)
+			}
+
+			return
+		}
+
+		var x any = bytes.Clone(buf[:n])
+		if s.cfg.TagRemoteAddr {
+			x = SocketElement{RemoteAddr: addr, Data: x.([]byte)}
+		}
+
+		select {
+		case s.out <- x:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
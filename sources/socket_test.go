@@ -0,0 +1,99 @@
+package sources_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTCP(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := sources.DefaultListenerConfig()
+	cfg.TagRemoteAddr = true
+
+	src, err := sources.TCP(ctx, "127.0.0.1:0", cfg)
+	require.NoError(t, err)
+
+	for _, line := range []string{"foo\nbar\n", "baz\n"} {
+		conn, err := net.Dial("tcp", src.Addr().String())
+		require.NoError(t, err)
+
+		_, err = conn.Write([]byte(line))
+		require.NoError(t, err)
+		require.NoError(t, conn.Close())
+	}
+
+	elements := []string{}
+	for range 3 {
+		x := receive(t, src.Out()).(sources.SocketElement)
+		require.NotNil(t, x.RemoteAddr)
+		elements = append(elements, string(x.Data))
+	}
+	require.ElementsMatch(t, []string{"foo", "bar", "baz"}, elements)
+
+	cancel()
+	require.Empty(t, channels.Slice[any](src.Out()))
+	require.NoError(t, src.Error())
+}
+
+func TestUDP(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	src, err := sources.UDP(ctx, "127.0.0.1:0", nil)
+	require.NoError(t, err)
+
+	conn, err := net.Dial("udp", src.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("foo"))
+	require.NoError(t, err)
+
+	require.Equal(t, []byte("foo"), receive(t, src.Out()))
+
+	cancel()
+	require.Empty(t, channels.Slice[any](src.Out()))
+	require.NoError(t, src.Error())
+}
+
+func TestTCP_NilFraming(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	src, err := sources.TCP(ctx, "127.0.0.1:0", &sources.ListenerConfig{TagRemoteAddr: true})
+	require.NoError(t, err)
+
+	conn, err := net.Dial("tcp", src.Addr().String())
+	require.NoError(t, err)
+
+	_, err = conn.Write([]byte("foo\n"))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	x := receive(t, src.Out()).(sources.SocketElement)
+	require.Equal(t, "foo", string(x.Data))
+}
+
+func TestUDP_ZeroDatagramSize(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	src, err := sources.UDP(ctx, "127.0.0.1:0", &sources.PacketConfig{})
+	require.NoError(t, err)
+
+	conn, err := net.Dial("udp", src.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("foo"))
+	require.NoError(t, err)
+
+	require.Equal(t, []byte("foo"), receive(t, src.Out()))
+}
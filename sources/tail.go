@@ -0,0 +1,344 @@
+package sources
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/katallaxie/streams"
+)
+
+var _ streams.Sourceable = (*TailSource)(nil)
+
+// TailConfig holds the configuration for a file tail source.
+type TailConfig struct {
+	// FromEnd starts at the end of the file instead of the beginning.
+	FromEnd bool
+	// PollInterval is the interval to check the file for changes.
+	PollInterval time.Duration
+	// MaxLineSize is the maximum size of a line in bytes.
+	MaxLineSize int
+	// OffsetFile persists the read offset, so that the source resumes where it left off.
+	OffsetFile string
+}
+
+// DefaultTailConfig returns a default tail configuration.
+func DefaultTailConfig() *TailConfig {
+	return &TailConfig{
+		PollInterval: 250 * time.Millisecond,
+		MaxLineSize:  DefaultMaxElementSize,
+	}
+}
+
+// TailOffset is the persisted read position of a tailed file.
+type TailOffset struct {
+	// Inode identifies the file, it is zero if not supported by the platform.
+	Inode uint64 `json:"inode"`
+	// Offset is the byte offset of the next line.
+	Offset int64 `json:"offset"`
+}
+
+// TailSource follows the lines appended to a file like `tail -F`.
+// It detects truncation and rename-based rotation by polling the file.
+type TailSource struct {
+	path    string
+	cfg     *TailConfig
+	out     chan any
+	err     error
+	errOnce sync.Once
+
+	file   *os.File
+	info   fs.FileInfo
+	reader *bufio.Reader
+	offset int64
+	line   []byte
+}
+
+// Tail returns a new source that follows the lines appended to a file.
+func Tail(ctx context.Context, path string, cfg *TailConfig) (*TailSource, error) {
+	return NewTailSource(ctx, path, cfg)
+}
+
+// NewTailSource returns a new source that follows the lines appended to a file.
+// Zero values of the configuration are replaced by the defaults.
+func NewTailSource(ctx context.Context, path string, cfg *TailConfig) (*TailSource, error) {
+	defaults := DefaultTailConfig()
+	if cfg == nil {
+		cfg = defaults
+	}
+
+	c := *cfg
+	if c.PollInterval <= 0 {
+		c.PollInterval = defaults.PollInterval
+	}
+
+	if c.MaxLineSize <= 0 {
+		c.MaxLineSize = defaults.MaxLineSize
+	}
+
+	t := &TailSource{
+		path: path,
+		cfg:  &c,
+		out:  make(chan any),
+	}
+
+	go t.attach(ctx)
+
+	return t, nil
+}
+
+// Error returns the error.
+func (t *TailSource) Error() error {
+	return t.err
+}
+
+func (t *TailSource) fail(err error) {
+	t.errOnce.Do(func() {
+		t.err = err
+	})
+}
+
+// Pipe pipes the output channel to the input channel.
+func (t *TailSource) Pipe(operator streams.Operatable) streams.Operatable {
+	streams.Pipe(t, operator)
+	return operator
+}
+
+// Out returns the output channel.
+func (t *TailSource) Out() <-chan any {
+	return t.out
+}
+
+func (t *TailSource) attach(ctx context.Context) {
+	defer close(t.out)
+	defer t.close()
+
+	ticker := time.NewTicker(t.cfg.PollInterval)
+	defer ticker.Stop()
+
+	first := true
+	for {
+		if err := t.poll(ctx, first); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				t.fail(err)
+			}
+
+			return
+		}
+
+		// a file that appears later is read from the start like tail -F
+		first = false
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll reads all complete lines and checks the file for truncation and rotation.
+func (t *TailSource) poll(ctx context.Context, first bool) error {
+	if t.file == nil {
+		if err := t.open(first); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+
+			return err
+		}
+	}
+
+	if err := t.read(ctx); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(t.path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil // the file has been moved and the new file is not yet created
+	case err != nil:
+		return err
+	case !os.SameFile(t.info, info):
+		// the file has been rotated, read the rest of the old file and follow the new one
+		if err := t.read(ctx); err != nil {
+			return err
+		}
+
+		if err := t.emit(ctx, t.line); err != nil {
+			return err
+		}
+		t.close()
+
+		return t.open(false)
+	case info.Size() < t.offset:
+		// the file has been truncated
+		t.seek(0)
+	}
+
+	return nil
+}
+
+func (t *TailSource) open(first bool) error {
+	f, err := os.Open(t.path)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	t.file = f
+	t.info = info
+	t.reader = bufio.NewReader(f)
+
+	offset := int64(0)
+	if first {
+		offset, err = t.initialOffset(info)
+		if err != nil {
+			return err
+		}
+	}
+
+	t.seek(offset)
+
+	return nil
+}
+
+func (t *TailSource) initialOffset(info fs.FileInfo) (int64, error) {
+	if t.cfg.OffsetFile != "" {
+		b, err := os.ReadFile(t.cfg.OffsetFile)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return 0, err
+		}
+
+		var offset TailOffset
+		if err == nil {
+			if err := json.Unmarshal(b, &offset); err != nil {
+				return 0, err
+			}
+
+			if offset.Inode == inode(info) && offset.Offset <= info.Size() {
+				return offset.Offset, nil
+			}
+		}
+	}
+
+	if t.cfg.FromEnd {
+		return info.Size(), nil
+	}
+
+	return 0, nil
+}
+
+func (t *TailSource) seek(offset int64) {
+	t.offset, _ = t.file.Seek(offset, io.SeekStart)
+	t.reader.Reset(t.file)
+	t.line = nil
+}
+
+func (t *TailSource) read(ctx context.Context) (err error) {
+	read := false
+	defer func() {
+		if read && (err == nil || errors.Is(err, context.Canceled)) {
+			if serr := t.save(); serr != nil && err == nil {
+				err = serr
+			}
+		}
+	}()
+
+	for {
+		b, err := t.reader.ReadSlice('\n')
+		t.line = append(t.line, b...)
+
+		if len(t.line) > t.cfg.MaxLineSize+1 {
+			return &FramingError{Offset: t.offset, Err: ErrElementTooLarge}
+		}
+
+		if errors.Is(err, bufio.ErrBufferFull) {
+			continue
+		}
+
+		if errors.Is(err, io.EOF) {
+			break // keep the partial line until it is completed
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := t.emit(ctx, bytes.TrimSuffix(bytes.TrimSuffix(t.line, []byte("\n")), []byte("\r"))); err != nil {
+			return err
+		}
+
+		t.offset += int64(len(t.line))
+		t.line = nil
+		read = true
+	}
+
+	return nil
+}
+
+func (t *TailSource) emit(ctx context.Context, line []byte) error {
+	if len(line) == 0 {
+		return nil
+	}
+
+	select {
+	case t.out <- bytes.Clone(line):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *TailSource) save() error {
+	if t.cfg.OffsetFile == "" {
+		return nil
+	}
+
+	b, err := json.Marshal(TailOffset{Inode: inode(t.info), Offset: t.offset})
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(t.cfg.OffsetFile, b)
+}
+
+// writeFileAtomic writes a file by renaming a temporary file in the same directory.
+func writeFileAtomic(path string, b []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (t *TailSource) close() {
+	if t.file != nil {
+		_ = t.file.Close()
+		t.file = nil
+	}
+}
@@ -0,0 +1,166 @@
+package sources_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/require"
+)
+
+func next(t *testing.T, src *sources.TailSource) string {
+	t.Helper()
+
+	select {
+	case x, ok := <-src.Out():
+		require.True(t, ok)
+		return string(x.([]byte))
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timeout waiting for line")
+	}
+
+	return ""
+}
+
+func appendFile(t *testing.T, path, s string) {
+	t.Helper()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	defer f.Close()
+
+	_, err = f.WriteString(s)
+	require.NoError(t, err)
+}
+
+func tailConfig(offsetFile string) *sources.TailConfig {
+	cfg := sources.DefaultTailConfig()
+	cfg.PollInterval = 10 * time.Millisecond
+	cfg.OffsetFile = offsetFile
+
+	return cfg
+}
+
+func TestTail(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	appendFile(t, path, "foo\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	src, err := sources.Tail(ctx, path, tailConfig(""))
+	require.NoError(t, err)
+
+	require.Equal(t, "foo", next(t, src))
+
+	appendFile(t, path, "b")
+	appendFile(t, path, "ar\n")
+	require.Equal(t, "bar", next(t, src))
+
+	// rotation
+	require.NoError(t, os.Rename(path, path+".1"))
+	appendFile(t, path+".1", "baz\n")
+	appendFile(t, path, "qux\n")
+	require.Equal(t, "baz", next(t, src))
+	require.Equal(t, "qux", next(t, src))
+
+	// truncation
+	require.NoError(t, os.Truncate(path, 0))
+	time.Sleep(50 * time.Millisecond)
+	appendFile(t, path, "a\n")
+	require.Equal(t, "a", next(t, src))
+
+	cancel()
+	for range src.Out() {
+	}
+	require.NoError(t, src.Error())
+}
+
+func TestTail_FromEnd(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	appendFile(t, path, "foo\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := tailConfig("")
+	cfg.FromEnd = true
+
+	src, err := sources.Tail(ctx, path, cfg)
+	require.NoError(t, err)
+
+	time.Sleep(50 * time.Millisecond)
+	appendFile(t, path, "bar\n")
+	require.Equal(t, "bar", next(t, src))
+}
+
+func TestTail_FromEndNewFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := tailConfig("")
+	cfg.FromEnd = true
+
+	src, err := sources.Tail(ctx, path, cfg)
+	require.NoError(t, err)
+
+	// a file that appears later is read from the start
+	time.Sleep(50 * time.Millisecond)
+	appendFile(t, path+".tmp", "foo\n")
+	require.NoError(t, os.Rename(path+".tmp", path))
+	require.Equal(t, "foo", next(t, src))
+}
+
+func TestTail_Resume(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	offsetFile := filepath.Join(dir, "app.offset")
+	appendFile(t, path, "foo\nbar\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	src, err := sources.Tail(ctx, path, tailConfig(offsetFile))
+	require.NoError(t, err)
+	require.Equal(t, "foo", next(t, src))
+	require.Equal(t, "bar", next(t, src))
+
+	cancel()
+	for range src.Out() {
+	}
+
+	appendFile(t, path, "baz\n")
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	src, err = sources.Tail(ctx, path, tailConfig(offsetFile))
+	require.NoError(t, err)
+	require.Equal(t, "baz", next(t, src))
+}
+
+func TestTail_ZeroConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	appendFile(t, path, "foo\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	src, err := sources.Tail(ctx, path, &sources.TailConfig{})
+	require.NoError(t, err)
+
+	require.Equal(t, "foo", next(t, src))
+
+	cancel()
+	for range src.Out() {
+	}
+	require.NoError(t, src.Error())
+}
@@ -0,0 +1,461 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/katallaxie/streams"
+)
+
+var _ streams.Sourceable = (*WatchSource)(nil)
+
+// FileOp is the operation of a file event.
+type FileOp int
+
+const (
+	// FileCreated is emitted for a new file.
+	FileCreated FileOp = iota + 1
+	// FileModified is emitted if the size or modification time of a file changed.
+	FileModified
+	// FileRemoved is emitted if a file is gone.
+	FileRemoved
+)
+
+// String returns the name of the operation.
+func (o FileOp) String() string {
+	switch o {
+	case FileCreated:
+		return "created"
+	case FileModified:
+		return "modified"
+	case FileRemoved:
+		return "removed"
+	default:
+		return "unknown"
+	}
+}
+
+// FileEvent is emitted by the watch source for a changed file.
+type FileEvent struct {
+	// Op is the operation.
+	Op FileOp
+	// Path is the path of the file.
+	Path string
+	// Size is the size of the file.
+	Size int64
+	// ModTime is the modification time of the file.
+	ModTime time.Time
+}
+
+// WatchConfig holds the configuration for a directory watch source.
+type WatchConfig struct {
+	// Pattern is a glob that the file names have to match, e.g. "*.csv".
+	Pattern string
+	// Recursive watches the subdirectories.
+	Recursive bool
+	// PollInterval is the interval to scan the directory.
+	PollInterval time.Duration
+	// Framing streams the contents of new files instead of emitting file events.
+	// A file is read once its size and modification time did not change for a poll interval.
+	Framing Framing
+	// ManifestFile persists the processed files, so that restarts don't reprocess them.
+	ManifestFile string
+	// ArchiveDir is the directory to move the files to after their contents have been read.
+	// It must be on the same filesystem as the watched directory.
+	ArchiveDir string
+	// OnError is called with the FramingError of a file that fails to be framed.
+	// The file is added to the manifest and skipped, it is not archived.
+	OnError func(path string, err error)
+}
+
+// DefaultWatchConfig returns a default watch configuration.
+func DefaultWatchConfig() *WatchConfig {
+	return &WatchConfig{
+		PollInterval: time.Second,
+	}
+}
+
+// fileState is the state of a file in the manifest.
+type fileState struct {
+	Size    int64 `json:"size"`
+	ModTime int64 `json:"mod_time"`
+}
+
+// WatchSource watches a directory for new, modified and removed files.
+// Hidden files are ignored.
+type WatchSource struct {
+	dir      string
+	cfg      *WatchConfig
+	out      chan any
+	err      error
+	errOnce  sync.Once
+	manifest map[string]fileState
+	pending  map[string]fileState
+}
+
+// Watch returns a new source that watches a directory.
+func Watch(ctx context.Context, dir string, cfg *WatchConfig) (*WatchSource, error) {
+	return NewWatchSource(ctx, dir, cfg)
+}
+
+// NewWatchSource returns a new source that watches a directory.
+// Zero values of the configuration are replaced by the defaults.
+func NewWatchSource(ctx context.Context, dir string, cfg *WatchConfig) (*WatchSource, error) {
+	defaults := DefaultWatchConfig()
+	if cfg == nil {
+		cfg = defaults
+	}
+
+	c := *cfg
+	if c.PollInterval <= 0 {
+		c.PollInterval = defaults.PollInterval
+	}
+
+	if _, err := filepath.Match(c.Pattern, ""); err != nil {
+		return nil, err
+	}
+
+	w := &WatchSource{
+		dir:      dir,
+		cfg:      &c,
+		out:      make(chan any),
+		manifest: make(map[string]fileState),
+		pending:  make(map[string]fileState),
+	}
+
+	if err := w.load(); err != nil {
+		return nil, err
+	}
+
+	go w.attach(ctx)
+
+	return w, nil
+}
+
+// Error returns the error.
+func (w *WatchSource) Error() error {
+	return w.err
+}
+
+func (w *WatchSource) fail(err error) {
+	w.errOnce.Do(func() {
+		w.err = err
+	})
+}
+
+// Pipe pipes the output channel to the input channel.
+func (w *WatchSource) Pipe(operator streams.Operatable) streams.Operatable {
+	streams.Pipe(w, operator)
+	return operator
+}
+
+// Out returns the output channel.
+func (w *WatchSource) Out() <-chan any {
+	return w.out
+}
+
+func (w *WatchSource) attach(ctx context.Context) {
+	defer close(w.out)
+
+	ticker := time.NewTicker(w.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		err := w.poll(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			w.fail(err)
+		}
+
+		if err != nil {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *WatchSource) poll(ctx context.Context) error {
+	files, err := w.scan()
+	if err != nil {
+		return err
+	}
+
+	if w.cfg.Framing == nil {
+		return w.events(ctx, files)
+	}
+
+	return w.contents(ctx, files)
+}
+
+// events emits the differences between the manifest and the files.
+func (w *WatchSource) events(ctx context.Context, files map[string]fileState) error {
+	changed := false
+
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		state := files[name]
+
+		op := FileCreated
+		if old, ok := w.manifest[name]; ok {
+			if old == state {
+				continue
+			}
+			op = FileModified
+		}
+
+		if err := w.emit(ctx, FileEvent{Op: op, Path: filepath.Join(w.dir, name), Size: state.Size, ModTime: time.Unix(0, state.ModTime)}); err != nil {
+			return err
+		}
+
+		w.manifest[name] = state
+		changed = true
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(w.manifest)) {
+		if _, ok := files[name]; ok {
+			continue
+		}
+
+		if err := w.emit(ctx, FileEvent{Op: FileRemoved, Path: filepath.Join(w.dir, name)}); err != nil {
+			return err
+		}
+
+		delete(w.manifest, name)
+		changed = true
+	}
+
+	if changed {
+		return w.save()
+	}
+
+	return nil
+}
+
+// contents streams the contents of the files that settled since the last poll.
+func (w *WatchSource) contents(ctx context.Context, files map[string]fileState) error {
+	for name := range w.manifest {
+		if _, ok := files[name]; !ok {
+			delete(w.manifest, name)
+		}
+	}
+
+	for name := range w.pending {
+		if _, ok := files[name]; !ok {
+			delete(w.pending, name)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		state := files[name]
+
+		if old, ok := w.manifest[name]; ok && old == state {
+			continue
+		}
+
+		if p, ok := w.pending[name]; !ok || p != state {
+			w.pending[name] = state
+			continue
+		}
+		delete(w.pending, name)
+
+		if err := w.read(ctx, name); err != nil {
+			var ferr *FramingError
+			if !errors.As(err, &ferr) {
+				return err
+			}
+
+			if w.cfg.OnError != nil {
+				w.cfg.OnError(filepath.Join(w.dir, name), err)
+			}
+
+			w.manifest[name] = state
+			if err := w.save(); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		w.manifest[name] = state
+
+		if err := w.archive(name); err != nil {
+			return err
+		}
+
+		if err := w.save(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *WatchSource) read(ctx context.Context, name string) error {
+	f, err := os.Open(filepath.Join(w.dir, name))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	counter := &countingReader{r: f}
+	reader := w.cfg.Framing()
+
+	for {
+		b, err := reader(counter)
+		if len(b) > 0 {
+			if err := w.emit(ctx, b); err != nil {
+				return err
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			var ferr *FramingError
+			if !errors.As(err, &ferr) {
+				err = &FramingError{Offset: counter.n, Err: err}
+			}
+
+			return fmt.Errorf("%s: %w", f.Name(), err)
+		}
+	}
+}
+
+func (w *WatchSource) archive(name string) error {
+	if w.cfg.ArchiveDir == "" {
+		return nil
+	}
+
+	path := filepath.Join(w.cfg.ArchiveDir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	if err := os.Rename(filepath.Join(w.dir, name), path); err != nil {
+		return err
+	}
+
+	delete(w.manifest, name)
+
+	return nil
+}
+
+func (w *WatchSource) emit(ctx context.Context, x any) error {
+	select {
+	case w.out <- x:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// scan returns the state of the matching files by their path relative to the directory.
+func (w *WatchSource) scan() (map[string]fileState, error) {
+	files := make(map[string]fileState)
+
+	archive, _ := filepath.Abs(w.cfg.ArchiveDir)
+	manifest, _ := filepath.Abs(w.cfg.ManifestFile)
+
+	err := filepath.WalkDir(w.dir, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if path == w.dir {
+			return nil
+		}
+
+		if d.IsDir() {
+			abs, _ := filepath.Abs(path)
+			if !w.cfg.Recursive || strings.HasPrefix(d.Name(), ".") || (w.cfg.ArchiveDir != "" && abs == archive) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !d.Type().IsRegular() || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+
+		if abs, _ := filepath.Abs(path); w.cfg.ManifestFile != "" && abs == manifest {
+			return nil
+		}
+
+		if w.cfg.Pattern != "" {
+			if ok, _ := filepath.Match(w.cfg.Pattern, d.Name()); !ok {
+				return nil
+			}
+		}
+
+		info, err := d.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		name, err := filepath.Rel(w.dir, path)
+		if err != nil {
+			return err
+		}
+
+		files[name] = fileState{Size: info.Size(), ModTime: info.ModTime().UnixNano()}
+
+		return nil
+	})
+
+	return files, err
+}
+
+func (w *WatchSource) load() error {
+	if w.cfg.ManifestFile == "" {
+		return nil
+	}
+
+	b, err := os.ReadFile(w.cfg.ManifestFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, &w.manifest)
+}
+
+func (w *WatchSource) save() error {
+	if w.cfg.ManifestFile == "" {
+		return nil
+	}
+
+	b, err := json.Marshal(w.manifest)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(w.cfg.ManifestFile, b)
+}
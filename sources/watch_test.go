@@ -0,0 +1,161 @@
+package sources_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/require"
+)
+
+func receive(t *testing.T, out <-chan any) any {
+	t.Helper()
+
+	select {
+	case x, ok := <-out:
+		require.True(t, ok)
+		return x
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timeout waiting for element")
+	}
+
+	return nil
+}
+
+func watchConfig() *sources.WatchConfig {
+	cfg := sources.DefaultWatchConfig()
+	cfg.PollInterval = 10 * time.Millisecond
+
+	return cfg
+}
+
+func TestWatch_Events(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.csv"), []byte("a"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "c.csv"), []byte("c"), 0o600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := watchConfig()
+	cfg.Pattern = "*.csv"
+	cfg.Recursive = true
+
+	src, err := sources.Watch(ctx, dir, cfg)
+	require.NoError(t, err)
+
+	e := receive(t, src.Out()).(sources.FileEvent)
+	require.Equal(t, sources.FileCreated, e.Op)
+	require.Equal(t, filepath.Join(dir, "a.csv"), e.Path)
+
+	e = receive(t, src.Out()).(sources.FileEvent)
+	require.Equal(t, sources.FileCreated, e.Op)
+	require.Equal(t, filepath.Join(dir, "sub", "c.csv"), e.Path)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.csv"), []byte("aa"), 0o600))
+	e = receive(t, src.Out()).(sources.FileEvent)
+	require.Equal(t, sources.FileModified, e.Op)
+	require.Equal(t, int64(2), e.Size)
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "sub", "c.csv")))
+	e = receive(t, src.Out()).(sources.FileEvent)
+	require.Equal(t, sources.FileRemoved, e.Op)
+	require.Equal(t, filepath.Join(dir, "sub", "c.csv"), e.Path)
+}
+
+func TestWatch_Contents(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(t.TempDir(), "archive")
+	manifest := filepath.Join(t.TempDir(), "manifest.json")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.log"), []byte("foo\nbar\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.log"), []byte("baz\n"), 0o600))
+
+	cfg := watchConfig()
+	cfg.Framing = sources.Lines(sources.DefaultMaxElementSize)
+	cfg.ManifestFile = manifest
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	src, err := sources.Watch(ctx, dir, cfg)
+	require.NoError(t, err)
+
+	require.Equal(t, []byte("foo"), receive(t, src.Out()))
+	require.Equal(t, []byte("bar"), receive(t, src.Out()))
+	require.Equal(t, []byte("baz"), receive(t, src.Out()))
+
+	cancel()
+	for range src.Out() {
+	}
+	require.NoError(t, src.Error())
+
+	// restart with the manifest and archive new files
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "c.log"), []byte("qux\n"), 0o600))
+	cfg.ArchiveDir = archive
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	src, err = sources.Watch(ctx, dir, cfg)
+	require.NoError(t, err)
+
+	require.Equal(t, []byte("qux"), receive(t, src.Out()))
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(filepath.Join(archive, "c.log"))
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoFileExists(t, filepath.Join(dir, "c.log"))
+}
+
+func TestWatch_ZeroConfig(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.csv"), []byte("a"), 0o600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	src, err := sources.Watch(ctx, dir, &sources.WatchConfig{})
+	require.NoError(t, err)
+
+	e := receive(t, src.Out()).(sources.FileEvent)
+	require.Equal(t, sources.FileCreated, e.Op)
+
+	cancel()
+	for range src.Out() {
+	}
+	require.NoError(t, src.Error())
+}
+
+func TestWatch_FramingError(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.log"), []byte("{bad\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.log"), []byte("{\"b\":1}\n"), 0o600))
+
+	errs := make(chan error, 1)
+
+	cfg := watchConfig()
+	cfg.Framing = sources.NDJSON(sources.DefaultMaxElementSize)
+	cfg.OnError = func(path string, err error) {
+		require.Equal(t, filepath.Join(dir, "a.log"), path)
+		errs <- err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	src, err := sources.Watch(ctx, dir, cfg)
+	require.NoError(t, err)
+
+	require.Equal(t, []byte(`{"b":1}`), receive(t, src.Out()))
+	require.ErrorIs(t, <-errs, sources.ErrInvalidJSON)
+
+	cancel()
+	for range src.Out() {
+	}
+	require.NoError(t, src.Error())
+}
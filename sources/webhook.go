@@ -0,0 +1,225 @@
+package sources
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/katallaxie/streams"
+)
+
+var (
+	_ streams.Sourceable = (*WebhookSource)(nil)
+	_ http.Handler       = (*WebhookSource)(nil)
+)
+
+// BackpressurePolicy is the policy of the webhook source if the pipeline is full.
+type BackpressurePolicy int
+
+const (
+	// BlockRequest blocks the request until the element is accepted or the request is canceled.
+	BlockRequest BackpressurePolicy = iota
+	// RejectRequest rejects the request with 429 Too Many Requests if the pipeline is not ready to receive.
+	RejectRequest
+)
+
+// WebhookConfig holds the configuration for a webhook source.
+type WebhookConfig struct {
+	// Buffer is the number of elements that are buffered for the pipeline, it is unbuffered if zero.
+	// A buffered element is acknowledged once it is buffered, not once the pipeline has taken it.
+	Buffer int
+	// Policy is the backpressure policy if the pipeline is not ready to receive.
+	Policy BackpressurePolicy
+	// MaxBodySize is the maximum size of a request body in bytes without a Framing.
+	// With a Framing the size of each element is limited by the framing instead.
+	MaxBodySize int64
+	// Framing splits the request body into elements, e.g. NDJSON.
+	// If nil the request body is a single element.
+	Framing Framing
+}
+
+// DefaultWebhookConfig returns a default webhook configuration.
+func DefaultWebhookConfig() *WebhookConfig {
+	return &WebhookConfig{
+		MaxBodySize: DefaultMaxElementSize,
+	}
+}
+
+// WebhookSource is an http.Handler that turns POST requests into elements.
+// A request is acknowledged with 202 Accepted after its elements have been accepted by the pipeline.
+type WebhookSource struct {
+	cfg    *WebhookConfig
+	out    chan any
+	done   chan struct{}
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+	closed bool
+	err    error
+}
+
+// Webhook returns a new webhook source.
+func Webhook(cfg *WebhookConfig) *WebhookSource {
+	return NewWebhookSource(cfg)
+}
+
+// NewWebhookSource returns a new webhook source.
+// Zero values of the configuration are replaced by the defaults.
+func NewWebhookSource(cfg *WebhookConfig) *WebhookSource {
+	defaults := DefaultWebhookConfig()
+	if cfg == nil {
+		cfg = defaults
+	}
+
+	c := *cfg
+	if c.MaxBodySize <= 0 {
+		c.MaxBodySize = defaults.MaxBodySize
+	}
+
+	return &WebhookSource{
+		cfg:  &c,
+		out:  make(chan any, c.Buffer),
+		done: make(chan struct{}),
+	}
+}
+
+// Error returns the error.
+func (s *WebhookSource) Error() error {
+	return s.err
+}
+
+// Pipe pipes the output channel to the input channel.
+func (s *WebhookSource) Pipe(operator streams.Operatable) streams.Operatable {
+	streams.Pipe(s, operator)
+	return operator
+}
+
+// Out returns the output channel.
+func (s *WebhookSource) Out() <-chan any {
+	return s.out
+}
+
+// Close stops accepting requests and closes the output channel
+// after the pending requests are done.
+func (s *WebhookSource) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.done)
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	close(s.out)
+
+	return nil
+}
+
+// ServeHTTP implements http.Handler.
+func (s *WebhookSource) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+
+		return
+	}
+	s.wg.Add(1)
+	s.mu.Unlock()
+	defer s.wg.Done()
+
+	// a framed body may be a long-lived stream, so only the elements are limited
+	body := r.Body
+	if s.cfg.Framing == nil {
+		body = http.MaxBytesReader(w, r.Body, s.cfg.MaxBodySize)
+	}
+	defer body.Close()
+
+	accepted := 0
+	status, err := s.receive(r, body, &accepted)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("%v: %d elements accepted", err, accepted), status)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *WebhookSource) receive(r *http.Request, body io.Reader, accepted *int) (int, error) {
+	if s.cfg.Framing == nil {
+		b, err := io.ReadAll(body)
+		if err != nil {
+			return bodyStatus(err), err
+		}
+
+		return s.emit(r, b, accepted)
+	}
+
+	reader := s.cfg.Framing()
+	counter := &countingReader{r: body}
+	for {
+		b, err := reader(counter)
+		if len(b) > 0 {
+			if status, err := s.emit(r, b, accepted); err != nil {
+				return status, err
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			return http.StatusAccepted, nil
+		}
+
+		if err != nil {
+			var ferr *FramingError
+			if !errors.As(err, &ferr) {
+				err = &FramingError{Offset: counter.n, Err: err}
+			}
+
+			return bodyStatus(err), err
+		}
+	}
+}
+
+func (s *WebhookSource) emit(r *http.Request, b []byte, accepted *int) (int, error) {
+	element := bytes.Clone(b)
+
+	if s.cfg.Policy == RejectRequest {
+		select {
+		case s.out <- element:
+			*accepted++
+			return http.StatusAccepted, nil
+		default:
+			return http.StatusTooManyRequests, errors.New("pipeline is full")
+		}
+	}
+
+	select {
+	case s.out <- element:
+		*accepted++
+		return http.StatusAccepted, nil
+	case <-r.Context().Done():
+		return http.StatusServiceUnavailable, r.Context().Err()
+	case <-s.done:
+		return http.StatusServiceUnavailable, errors.New("source is closed")
+	}
+}
+
+func bodyStatus(err error) int {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) || errors.Is(err, ErrElementTooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+
+	return http.StatusBadRequest
+}
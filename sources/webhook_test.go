@@ -0,0 +1,125 @@
+package sources_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook(t *testing.T) {
+	src := sources.Webhook(nil)
+
+	srv := httptest.NewServer(src)
+	defer srv.Close()
+
+	received := make(chan any, 1)
+	go func() {
+		received <- <-src.Out()
+	}()
+
+	res, err := http.Post(srv.URL, "application/json", strings.NewReader(`{"foo":"bar"}`))
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusAccepted, res.StatusCode)
+	require.Equal(t, []byte(`{"foo":"bar"}`), <-received)
+}
+
+func TestWebhook_NDJSON(t *testing.T) {
+	cfg := sources.DefaultWebhookConfig()
+	cfg.Framing = sources.NDJSON(sources.DefaultMaxElementSize)
+	cfg.Buffer = 3
+	cfg.MaxBodySize = 8 // a framed body is limited per element
+
+	src := sources.Webhook(cfg)
+
+	rec := httptest.NewRecorder()
+	src.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{\"a\":1}\n{\"b\":2}\n")))
+	require.Equal(t, http.StatusAccepted, rec.Code)
+
+	require.NoError(t, src.Close())
+
+	elements := []string{}
+	for x := range src.Out() {
+		elements = append(elements, string(x.([]byte)))
+	}
+	require.Equal(t, []string{`{"a":1}`, `{"b":2}`}, elements)
+}
+
+func TestWebhook_Status(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    func(*sources.WebhookConfig)
+		method string
+		body   string
+		status int
+	}{
+		{
+			name:   "method not allowed",
+			method: http.MethodGet,
+			status: http.StatusMethodNotAllowed,
+		},
+		{
+			name:   "pipeline full",
+			cfg:    func(cfg *sources.WebhookConfig) { cfg.Policy = sources.RejectRequest },
+			method: http.MethodPost,
+			body:   "foo",
+			status: http.StatusTooManyRequests,
+		},
+		{
+			name: "buffer full",
+			cfg: func(cfg *sources.WebhookConfig) {
+				cfg.Policy = sources.RejectRequest
+				cfg.Buffer = 1
+				cfg.Framing = sources.Lines(sources.DefaultMaxElementSize)
+			},
+			method: http.MethodPost,
+			body:   "foo\nbar\n",
+			status: http.StatusTooManyRequests,
+		},
+		{
+			name: "element too large",
+			cfg: func(cfg *sources.WebhookConfig) {
+				cfg.Framing = sources.Lines(2)
+			},
+			method: http.MethodPost,
+			body:   "foo\n",
+			status: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:   "body too large",
+			cfg:    func(cfg *sources.WebhookConfig) { cfg.MaxBodySize = 2 },
+			method: http.MethodPost,
+			body:   "foo",
+			status: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "invalid json",
+			cfg: func(cfg *sources.WebhookConfig) {
+				cfg.Framing = sources.NDJSON(sources.DefaultMaxElementSize)
+			},
+			method: http.MethodPost,
+			body:   "foo\n",
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := sources.DefaultWebhookConfig()
+			if tt.cfg != nil {
+				tt.cfg(cfg)
+			}
+
+			src := sources.Webhook(cfg)
+
+			rec := httptest.NewRecorder()
+			src.ServeHTTP(rec, httptest.NewRequest(tt.method, "/", strings.NewReader(tt.body)))
+			require.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
@@ -9,8 +9,15 @@ import (
 // Pipe pipes the output channel to the input channel.
 func Pipe(stream Streamable, rev Receivable) {
 	go func() {
+		done := doneOf(rev)
+
+	loop:
 		for x := range stream.Out() {
-			rev.In() <- x
+			select {
+			case rev.In() <- x:
+			case <-done:
+				break loop
+			}
 		}
 
 		close(rev.In())
@@ -0,0 +1,105 @@
+package syslog
+
+import (
+	"net"
+	"strconv"
+	"time"
+)
+
+// Facility is the facility of a syslog message.
+type Facility int
+
+// The facilities of RFC 5424.
+const (
+	Kern Facility = iota
+	User
+	Mail
+	Daemon
+	Auth
+	Syslog
+	LPR
+	News
+	UUCP
+	Cron
+	AuthPriv
+	FTP
+	NTP
+	Security
+	Console
+	SolarisCron
+	Local0
+	Local1
+	Local2
+	Local3
+	Local4
+	Local5
+	Local6
+	Local7
+)
+
+var facilityNames = [...]string{
+	"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news", "uucp", "cron", "authpriv", "ftp",
+	"ntp", "security", "console", "solaris-cron", "local0", "local1", "local2", "local3", "local4", "local5",
+	"local6", "local7",
+}
+
+// String returns the name of the facility.
+func (f Facility) String() string {
+	if f >= 0 && int(f) < len(facilityNames) {
+		return facilityNames[f]
+	}
+
+	return strconv.Itoa(int(f))
+}
+
+// Severity is the severity of a syslog message.
+type Severity int
+
+// The severities of RFC 5424.
+const (
+	Emergency Severity = iota
+	Alert
+	Critical
+	Error
+	Warning
+	Notice
+	Informational
+	Debug
+)
+
+var severityNames = [...]string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	if s >= 0 && int(s) < len(severityNames) {
+		return severityNames[s]
+	}
+
+	return strconv.Itoa(int(s))
+}
+
+// Message is a parsed syslog message.
+type Message struct {
+	// Facility is the facility of the message.
+	Facility Facility
+	// Severity is the severity of the message.
+	Severity Severity
+	// Version is the version of RFC 5424 messages, it is zero for RFC 3164 messages.
+	Version int
+	// Timestamp is the time of the message, it is zero if the message has no timestamp.
+	Timestamp time.Time
+	// Hostname is the host that sent the message.
+	Hostname string
+	// AppName is the application that sent the message, the tag of RFC 3164 messages.
+	AppName string
+	// ProcID is the process of the application.
+	ProcID string
+	// MsgID is the type of the message.
+	MsgID string
+	// StructuredData maps the SD-IDs to their parameters.
+	StructuredData map[string]map[string]string
+	// Message is the free-form message.
+	Message string
+	// RemoteAddr is the address of the sender, it is nil for parsed messages.
+	RemoteAddr net.Addr
+}
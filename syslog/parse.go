@@ -0,0 +1,320 @@
+package syslog
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrInvalidPriority is returned if the message does not start with a valid priority.
+	ErrInvalidPriority = errors.New("syslog: invalid priority")
+	// ErrInvalidHeader is returned if the header of the message is incomplete.
+	ErrInvalidHeader = errors.New("syslog: invalid header")
+	// ErrInvalidTimestamp is returned if the timestamp of the message cannot be parsed.
+	ErrInvalidTimestamp = errors.New("syslog: invalid timestamp")
+	// ErrInvalidStructuredData is returned if the structured data of the message cannot be parsed.
+	ErrInvalidStructuredData = errors.New("syslog: invalid structured data")
+)
+
+// Parse parses an RFC 5424 or RFC 3164 message.
+// The year of RFC 3164 timestamps is inferred from the current time.
+func Parse(b []byte) (*Message, error) {
+	return parse(b, time.Now())
+}
+
+type parser struct {
+	b   []byte
+	pos int
+}
+
+func parse(b []byte, now time.Time) (*Message, error) {
+	p := &parser{b: bytes.TrimRight(b, "\r\n\x00")}
+
+	pri, err := p.priority()
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Message{
+		Facility: Facility(pri / 8),
+		Severity: Severity(pri % 8),
+	}
+
+	if version, ok := p.version(); ok {
+		m.Version = version
+		err = p.rfc5424(m)
+	} else {
+		err = p.rfc3164(m, now)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.b)
+}
+
+func (p *parser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+
+	return p.b[p.pos]
+}
+
+func (p *parser) priority() (int, error) {
+	if p.peek() != '<' {
+		return 0, ErrInvalidPriority
+	}
+
+	end := bytes.IndexByte(p.b, '>')
+	if end < 2 || end > 4 {
+		return 0, ErrInvalidPriority
+	}
+
+	pri, err := strconv.Atoi(string(p.b[1:end]))
+	if err != nil || pri < 0 || pri > 191 {
+		return 0, ErrInvalidPriority
+	}
+	p.pos = end + 1
+
+	return pri, nil
+}
+
+// version parses the version of RFC 5424, which is followed by a space.
+func (p *parser) version() (int, bool) {
+	end := p.pos
+	for end < len(p.b) && end-p.pos < 3 && p.b[end] >= '0' && p.b[end] <= '9' {
+		end++
+	}
+
+	if end == p.pos || end >= len(p.b) || p.b[end] != ' ' || p.b[p.pos] == '0' {
+		return 0, false
+	}
+
+	version, _ := strconv.Atoi(string(p.b[p.pos:end]))
+	p.pos = end + 1
+
+	return version, true
+}
+
+// field returns the next header field of RFC 5424, the nil value "-" is returned as empty string.
+func (p *parser) field() (string, error) {
+	end := bytes.IndexByte(p.b[p.pos:], ' ')
+	if end < 1 {
+		return "", ErrInvalidHeader
+	}
+
+	f := string(p.b[p.pos : p.pos+end])
+	p.pos += end + 1
+
+	if f == "-" {
+		return "", nil
+	}
+
+	return f, nil
+}
+
+func (p *parser) rfc5424(m *Message) error {
+	ts, err := p.field()
+	if err != nil {
+		return err
+	}
+
+	if ts != "" {
+		m.Timestamp, err = time.Parse(time.RFC3339Nano, ts)
+		if err != nil {
+			return ErrInvalidTimestamp
+		}
+	}
+
+	for _, f := range []*string{&m.Hostname, &m.AppName, &m.ProcID, &m.MsgID} {
+		if *f, err = p.field(); err != nil {
+			return err
+		}
+	}
+
+	if err := p.structuredData(m); err != nil {
+		return err
+	}
+
+	if p.eof() {
+		return nil
+	}
+
+	if p.peek() != ' ' {
+		return ErrInvalidStructuredData
+	}
+	p.pos++
+
+	m.Message = string(bytes.TrimPrefix(p.b[p.pos:], []byte("\xef\xbb\xbf")))
+
+	return nil
+}
+
+func (p *parser) structuredData(m *Message) error {
+	if p.peek() == '-' {
+		p.pos++
+		return nil
+	}
+
+	if p.peek() != '[' {
+		return ErrInvalidStructuredData
+	}
+
+	m.StructuredData = make(map[string]map[string]string)
+
+	for p.peek() == '[' {
+		p.pos++
+
+		id := p.name()
+		if id == "" {
+			return ErrInvalidStructuredData
+		}
+
+		params := make(map[string]string)
+		for p.peek() == ' ' {
+			p.pos++
+
+			name := p.name()
+			if name == "" || p.peek() != '=' {
+				return ErrInvalidStructuredData
+			}
+			p.pos++
+
+			value, err := p.quoted()
+			if err != nil {
+				return err
+			}
+			params[name] = value
+		}
+
+		if p.peek() != ']' {
+			return ErrInvalidStructuredData
+		}
+		p.pos++
+
+		m.StructuredData[id] = params
+	}
+
+	return nil
+}
+
+// name returns an SD-ID or a parameter name.
+func (p *parser) name() string {
+	start := p.pos
+	for !p.eof() {
+		switch c := p.peek(); {
+		case c == ' ' || c == '=' || c == ']' || c == '"' || c <= 32 || c >= 127:
+			return string(p.b[start:p.pos])
+		}
+		p.pos++
+	}
+
+	return string(p.b[start:p.pos])
+}
+
+// quoted returns a parameter value, in which '"', '\' and ']' are escaped with a backslash.
+func (p *parser) quoted() (string, error) {
+	if p.peek() != '"' {
+		return "", ErrInvalidStructuredData
+	}
+	p.pos++
+
+	var value []byte
+	for !p.eof() {
+		c := p.b[p.pos]
+		p.pos++
+
+		switch {
+		case c == '"':
+			return string(value), nil
+		case c == '\\' && !p.eof() && (p.peek() == '"' || p.peek() == '\\' || p.peek() == ']'):
+			value = append(value, p.peek())
+			p.pos++
+		default:
+			value = append(value, c)
+		}
+	}
+
+	return "", ErrInvalidStructuredData
+}
+
+func (p *parser) rfc3164(m *Message, now time.Time) error {
+	if p.peek() == ' ' {
+		p.pos++
+	}
+
+	if err := p.timestamp3164(m, now); err != nil {
+		return err
+	}
+
+	if p.peek() != ' ' {
+		return ErrInvalidHeader
+	}
+	p.pos++
+
+	// the hostname is optional, a token that ends with a colon is a tag
+	if end := bytes.IndexByte(p.b[p.pos:], ' '); end > 0 && p.b[p.pos+end-1] != ':' && p.b[p.pos+end-1] != ']' {
+		m.Hostname = string(p.b[p.pos : p.pos+end])
+		p.pos += end + 1
+	}
+
+	rest := p.b[p.pos:]
+	end := bytes.IndexAny(rest, ":[ ")
+	if end > 0 && rest[end] != ' ' {
+		m.AppName = string(rest[:end])
+		rest = rest[end:]
+
+		if rest[0] == '[' {
+			pid := bytes.IndexByte(rest, ']')
+			if pid < 0 {
+				return ErrInvalidHeader
+			}
+			m.ProcID = string(rest[1:pid])
+			rest = rest[pid+1:]
+		}
+
+		rest = bytes.TrimPrefix(rest, []byte(":"))
+		rest = bytes.TrimPrefix(rest, []byte(" "))
+	}
+
+	m.Message = string(rest)
+
+	return nil
+}
+
+func (p *parser) timestamp3164(m *Message, now time.Time) error {
+	if len(p.b)-p.pos >= len(time.Stamp) {
+		if t, err := time.Parse(time.Stamp, string(p.b[p.pos:p.pos+len(time.Stamp)])); err == nil {
+			m.Timestamp = time.Date(now.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, now.Location())
+			if m.Timestamp.After(now.AddDate(0, 1, 0)) {
+				m.Timestamp = m.Timestamp.AddDate(-1, 0, 0)
+			}
+			p.pos += len(time.Stamp)
+
+			return nil
+		}
+	}
+
+	// some senders use RFC 3339 timestamps
+	end := bytes.IndexByte(p.b[p.pos:], ' ')
+	if end < 0 {
+		return ErrInvalidTimestamp
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, string(p.b[p.pos:p.pos+end]))
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+	m.Timestamp = t
+	p.pos += end
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package syslog_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/katallaxie/streams/syslog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		expected *syslog.Message
+		err      error
+	}{
+		{
+			name: "rfc 5424",
+			in:   "<34>1 2003-10-11T22:14:15.003Z mymachine.example.com su - ID47 - \xef\xbb\xbf'su root' failed for lonvick on /dev/pts/8",
+			expected: &syslog.Message{
+				Facility:  syslog.Auth,
+				Severity:  syslog.Critical,
+				Version:   1,
+				Timestamp: time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC),
+				Hostname:  "mymachine.example.com",
+				AppName:   "su",
+				MsgID:     "ID47",
+				Message:   "'su root' failed for lonvick on /dev/pts/8",
+			},
+		},
+		{
+			name: "rfc 5424 with structured data",
+			in:   `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog 42 ID47 [exampleSDID@32473 iut="3" eventSource="App\"lication\]"][examplePriority@32473 class="high"] An application event`,
+			expected: &syslog.Message{
+				Facility:  syslog.Local4,
+				Severity:  syslog.Notice,
+				Version:   1,
+				Timestamp: time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC),
+				Hostname:  "mymachine.example.com",
+				AppName:   "evntslog",
+				ProcID:    "42",
+				MsgID:     "ID47",
+				StructuredData: map[string]map[string]string{
+					"exampleSDID@32473":     {"iut": "3", "eventSource": `App"lication]`},
+					"examplePriority@32473": {"class": "high"},
+				},
+				Message: "An application event",
+			},
+		},
+		{
+			name: "rfc 5424 without message",
+			in:   "<13>1 - - - - - -",
+			expected: &syslog.Message{
+				Facility: syslog.User,
+				Severity: syslog.Notice,
+				Version:  1,
+			},
+		},
+		{
+			name: "rfc 3164",
+			in:   "<34>Oct 11 22:14:15 mymachine su: 'su root' failed for lonvick on /dev/pts/8\n",
+			expected: &syslog.Message{
+				Facility: syslog.Auth,
+				Severity: syslog.Critical,
+				Hostname: "mymachine",
+				AppName:  "su",
+				Message:  "'su root' failed for lonvick on /dev/pts/8",
+			},
+		},
+		{
+			name: "rfc 3164 with pid and without hostname",
+			in:   "<30>Feb  5 17:32:18 sshd[4123]: Accepted publickey",
+			expected: &syslog.Message{
+				Facility: syslog.Daemon,
+				Severity: syslog.Informational,
+				AppName:  "sshd",
+				ProcID:   "4123",
+				Message:  "Accepted publickey",
+			},
+		},
+		{
+			name: "missing priority",
+			in:   "Oct 11 22:14:15 mymachine su: failed",
+			err:  syslog.ErrInvalidPriority,
+		},
+		{
+			name: "invalid priority",
+			in:   "<192>Oct 11 22:14:15 mymachine su: failed",
+			err:  syslog.ErrInvalidPriority,
+		},
+		{
+			name: "incomplete header",
+			in:   "<34>1 2003-10-11T22:14:15.003Z mymachine",
+			err:  syslog.ErrInvalidHeader,
+		},
+		{
+			name: "invalid timestamp",
+			in:   "<34>1 yesterday mymachine su - ID47 - failed",
+			err:  syslog.ErrInvalidTimestamp,
+		},
+		{
+			name: "invalid structured data",
+			in:   `<34>1 - mymachine su - ID47 [id foo=bar] failed`,
+			err:  syslog.ErrInvalidStructuredData,
+		},
+		{
+			name: "rfc 3164 invalid timestamp",
+			in:   "<34>yesterday mymachine su: failed",
+			err:  syslog.ErrInvalidTimestamp,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := syslog.Parse([]byte(tt.in))
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+
+			if tt.expected.Version == 0 {
+				// the year of rfc 3164 timestamps is inferred
+				require.False(t, m.Timestamp.IsZero())
+				m.Timestamp = time.Time{}
+			}
+
+			require.Equal(t, tt.expected, m)
+		})
+	}
+}
+
+func TestFacilitySeverity_String(t *testing.T) {
+	require.Equal(t, "local4", syslog.Local4.String())
+	require.Equal(t, "crit", syslog.Critical.String())
+	require.Equal(t, "42", syslog.Facility(42).String())
+}
@@ -0,0 +1,239 @@
+package syslog
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync/atomic"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/sources"
+)
+
+var _ streams.Sourceable = (*Source)(nil)
+
+// ErrInvalidFrame is returned if the octet count of a frame is invalid.
+var ErrInvalidFrame = errors.New("syslog: invalid frame")
+
+// Config holds the configuration for a syslog source.
+type Config struct {
+	// Network is "udp", "tcp", "unix" or "unixgram".
+	Network string
+	// Address is the address to listen on, the path of unix sockets.
+	Address string
+	// MaxMessageSize is the maximum size of a message in bytes.
+	MaxMessageSize int
+	// DeadLetters receives the malformed messages and the framing errors of connections.
+	// They are emitted by the Errors output of the source if nil.
+	DeadLetters *streams.DeadLetterQueue
+	// ErrorBuffer is the size of the Errors output. Dead letters that do not fit are dropped and counted.
+	ErrorBuffer int
+}
+
+// DefaultConfig returns a default syslog configuration.
+func DefaultConfig() *Config {
+	return &Config{
+		Network:        "udp",
+		Address:        ":514",
+		MaxMessageSize: 64 << 10,
+		ErrorBuffer:    1024,
+	}
+}
+
+// Source is a source that receives syslog messages and emits them as *Message.
+// Malformed messages and framing errors are sent to the dead-letter queue, which has to be consumed,
+// or emitted as *streams.DeadLetter by the Errors output without one.
+type Source struct {
+	cfg     *Config
+	inner   streams.Sourceable
+	addr    net.Addr
+	out     chan any
+	errs    chan any
+	dropped atomic.Uint64
+}
+
+// NewSource returns a new syslog source that listens on the configured address.
+// Zero values of the configuration are replaced by the defaults.
+func NewSource(ctx context.Context, cfg *Config) (*Source, error) {
+	defaults := DefaultConfig()
+	if cfg == nil {
+		cfg = defaults
+	}
+
+	c := *cfg
+	if c.MaxMessageSize <= 0 {
+		c.MaxMessageSize = defaults.MaxMessageSize
+	}
+
+	if c.ErrorBuffer <= 0 {
+		c.ErrorBuffer = defaults.ErrorBuffer
+	}
+	cfg = &c
+
+	s := &Source{
+		cfg:  cfg,
+		out:  make(chan any),
+		errs: make(chan any, cfg.ErrorBuffer),
+	}
+
+	switch cfg.Network {
+	case "udp", "udp4", "udp6", "unixgram":
+		src, err := sources.ListenPacket(ctx, cfg.Network, cfg.Address, &sources.PacketConfig{MaxDatagramSize: cfg.MaxMessageSize, TagRemoteAddr: true})
+		if err != nil {
+			return nil, err
+		}
+		s.inner, s.addr = src, src.Addr()
+	case "tcp", "tcp4", "tcp6", "unix":
+		lcfg := &sources.ListenerConfig{
+			Framing:       Framing(cfg.MaxMessageSize),
+			TagRemoteAddr: true,
+			OnError: func(addr net.Addr, err error) {
+				s.deadLetter(ctx, sources.SocketElement{RemoteAddr: addr}, err)
+			},
+		}
+
+		src, err := sources.Listen(ctx, cfg.Network, cfg.Address, lcfg)
+		if err != nil {
+			return nil, err
+		}
+		s.inner, s.addr = src, src.Addr()
+	default:
+		return nil, fmt.Errorf("syslog: unsupported network %q", cfg.Network)
+	}
+
+	go s.attach(ctx)
+
+	return s, nil
+}
+
+// Addr returns the address of the source.
+func (s *Source) Addr() net.Addr {
+	return s.addr
+}
+
+// Errors returns the output of the malformed messages and framing errors if no dead-letter queue is configured.
+// It is closed together with the output channel.
+func (s *Source) Errors() <-chan any {
+	return s.errs
+}
+
+// Dropped returns the number of dead letters that were dropped because the Errors output was full.
+func (s *Source) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Error returns the error.
+func (s *Source) Error() error {
+	return s.inner.Error()
+}
+
+// Pipe pipes the output channel to the input channel.
+func (s *Source) Pipe(operator streams.Operatable) streams.Operatable {
+	streams.Pipe(s, operator)
+	return operator
+}
+
+// Out returns the output channel.
+func (s *Source) Out() <-chan any {
+	return s.out
+}
+
+func (s *Source) attach(ctx context.Context) {
+	defer func() {
+		channels.Drain(s.inner.Out())
+		close(s.out)
+		close(s.errs)
+	}()
+
+	for x := range s.inner.Out() {
+		element, ok := x.(sources.SocketElement)
+		if !ok {
+			continue
+		}
+
+		m, err := Parse(element.Data)
+		if err != nil {
+			s.deadLetter(ctx, element, err)
+			continue
+		}
+		m.RemoteAddr = element.RemoteAddr
+
+		select {
+		case s.out <- m:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Source) deadLetter(ctx context.Context, element sources.SocketElement, err error) {
+	if s.cfg.DeadLetters != nil {
+		s.cfg.DeadLetters.SendContext(ctx, "syslog", element, err)
+		return
+	}
+
+	select {
+	case s.errs <- &streams.DeadLetter{Element: element, Err: err, Stage: "syslog", Attempts: 1}:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// Framing returns a Framing for the octet-counting and the non-transparent framing of RFC 6587.
+// A frame that starts with a digit is octet-counted, other frames are terminated by a newline.
+func Framing(maxSize int) sources.Framing {
+	lines := sources.Lines(maxSize)
+
+	return func() sources.ElementReader {
+		var br *bufio.Reader
+		readLine := lines()
+
+		return func(r io.Reader) ([]byte, error) {
+			if br == nil {
+				br = bufio.NewReader(r)
+			}
+
+			c, err := br.Peek(1)
+			if err != nil {
+				return nil, err
+			}
+
+			// the lines read from the same buffered reader, because Lines does not wrap it again
+			if c[0] < '1' || c[0] > '9' {
+				return readLine(br)
+			}
+
+			n := 0
+			for {
+				b, err := br.ReadByte()
+				if err != nil {
+					return nil, io.ErrUnexpectedEOF
+				}
+
+				if b == ' ' {
+					break
+				}
+
+				if b < '0' || b > '9' {
+					return nil, ErrInvalidFrame
+				}
+
+				n = n*10 + int(b-'0')
+				if n > maxSize {
+					return nil, sources.ErrElementTooLarge
+				}
+			}
+
+			frame := make([]byte, n)
+			if _, err := io.ReadFull(br, frame); err != nil {
+				return nil, io.ErrUnexpectedEOF
+			}
+
+			return frame, nil
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package syslog_test
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/syslog"
+	"github.com/stretchr/testify/require"
+)
+
+func receive(t *testing.T, out <-chan any) any {
+	t.Helper()
+
+	select {
+	case x, ok := <-out:
+		require.True(t, ok)
+		return x
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timeout waiting for element")
+	}
+
+	return nil
+}
+
+func TestSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		network string
+		address string
+		send    []string
+	}{
+		{
+			name:    "udp",
+			network: "udp",
+			address: "127.0.0.1:0",
+			send:    []string{"<34>1 - host su - - - foo", "garbage", "<34>Oct 11 22:14:15 host su: bar"},
+		},
+		{
+			name:    "tcp",
+			network: "tcp",
+			address: "127.0.0.1:0",
+			send:    []string{"25 <34>1 - host su - - - foo", "garbage\n<34>Oct 11 22:14:15 host su: bar\n"},
+		},
+		{
+			name:    "unix",
+			network: "unix",
+			address: filepath.Join(t.TempDir(), "syslog.sock"),
+			send:    []string{"<34>1 - host su - - - foo\ngarbage\n25 <34>1 - host su - - - bar"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			cfg := syslog.DefaultConfig()
+			cfg.Network = tt.network
+			cfg.Address = tt.address
+			cfg.DeadLetters = streams.NewDeadLetterQueue()
+
+			src, err := syslog.NewSource(ctx, cfg)
+			require.NoError(t, err)
+
+			conn, err := net.Dial(tt.network, src.Addr().String())
+			require.NoError(t, err)
+			defer conn.Close()
+
+			for _, s := range tt.send {
+				_, err := conn.Write([]byte(s))
+				require.NoError(t, err)
+			}
+
+			m := receive(t, src.Out()).(*syslog.Message)
+			require.Equal(t, "foo", m.Message)
+			require.Equal(t, "host", m.Hostname)
+
+			dl := receive(t, cfg.DeadLetters.Out()).(*streams.DeadLetter)
+			require.Equal(t, "syslog", dl.Stage)
+			require.ErrorIs(t, dl.Err, syslog.ErrInvalidPriority)
+
+			m = receive(t, src.Out()).(*syslog.Message)
+			require.Equal(t, "bar", m.Message)
+			require.Equal(t, syslog.Auth, m.Facility)
+			require.Equal(t, syslog.Critical, m.Severity)
+		})
+	}
+}
+
+func TestSource_FramingError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := syslog.DefaultConfig()
+	cfg.Network = "tcp"
+	cfg.Address = "127.0.0.1:0"
+	cfg.DeadLetters = streams.NewDeadLetterQueue()
+
+	src, err := syslog.NewSource(ctx, cfg)
+	require.NoError(t, err)
+
+	conn, err := net.Dial("tcp", src.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("12x <34>1 - host su - - - foo"))
+	require.NoError(t, err)
+
+	dl := receive(t, cfg.DeadLetters.Out()).(*streams.DeadLetter)
+	require.Equal(t, "syslog", dl.Stage)
+	require.ErrorIs(t, dl.Err, syslog.ErrInvalidFrame)
+}
+
+func TestSource_Errors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := syslog.DefaultConfig()
+	cfg.Address = "127.0.0.1:0"
+
+	src, err := syslog.NewSource(ctx, cfg)
+	require.NoError(t, err)
+
+	conn, err := net.Dial("udp", src.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	for _, s := range []string{"garbage", "<34>1 - host su - - - foo"} {
+		_, err := conn.Write([]byte(s))
+		require.NoError(t, err)
+	}
+
+	m := receive(t, src.Out()).(*syslog.Message)
+	require.Equal(t, "foo", m.Message)
+
+	dl := receive(t, src.Errors()).(*streams.DeadLetter)
+	require.Equal(t, "syslog", dl.Stage)
+	require.ErrorIs(t, dl.Err, syslog.ErrInvalidPriority)
+	require.Zero(t, src.Dropped())
+}
+
+func TestSource_Dropped(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := syslog.DefaultConfig()
+	cfg.Address = "127.0.0.1:0"
+	cfg.ErrorBuffer = 1
+
+	src, err := syslog.NewSource(ctx, cfg)
+	require.NoError(t, err)
+
+	conn, err := net.Dial("udp", src.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	for _, s := range []string{"garbage", "garbage", "<34>1 - host su - - - foo"} {
+		_, err := conn.Write([]byte(s))
+		require.NoError(t, err)
+	}
+
+	m := receive(t, src.Out()).(*syslog.Message)
+	require.Equal(t, "foo", m.Message)
+	require.Equal(t, uint64(1), src.Dropped())
+
+	dl := receive(t, src.Errors()).(*streams.DeadLetter)
+	require.ErrorIs(t, dl.Err, syslog.ErrInvalidPriority)
+}
@@ -10,6 +10,7 @@ type TakeTimpl struct {
 	count int
 	in    chan any
 	out   chan any
+	canceler
 }
 
 // Take returns a new Take operator.
@@ -20,9 +21,10 @@ func Take(count int) *TakeTimpl {
 // NewTake creates a new Take operator.
 func NewTake(count int) *TakeTimpl {
 	t := &TakeTimpl{
-		count: count,
-		in:    make(chan any),
-		out:   make(chan any),
+		count:    count,
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
 	}
 
 	go t.attach()
@@ -59,8 +61,13 @@ func (t *TakeTimpl) Pipe(c Operatable) Operatable {
 }
 
 func (t *TakeTimpl) stream(recv Receivable) {
+	done := doneOf(recv)
 	for x := range t.out {
-		recv.In() <- x
+		select {
+		case recv.In() <- x:
+		case <-done:
+			t.cancel()
+		}
 	}
 
 	close(recv.In())
@@ -79,6 +86,7 @@ func (t *TakeTimpl) attach() {
 			}
 		}
 
+		t.cancel()
 		close(t.out)
 	}()
 }
@@ -45,3 +45,17 @@ func TestTake(t *testing.T) {
 		})
 	}
 }
+
+func TestTakeCancel(t *testing.T) {
+	recv := streams.Take(2)
+
+	go func() {
+		recv.In() <- 1
+		recv.In() <- 2
+	}()
+
+	output := channels.Slice[int](recv.Out())
+	require.Equal(t, []int{1, 2}, output)
+
+	<-recv.Done()
+}
@@ -0,0 +1,102 @@
+package streams
+
+var (
+	_ Streamable = (*TakeUntilImpl)(nil)
+	_ Receivable = (*TakeUntilImpl)(nil)
+	_ Cancelable = (*TakeUntilImpl)(nil)
+)
+
+// TakeUntilImpl takes elements until a signal stream emits and cancels the upstream afterwards.
+type TakeUntilImpl struct {
+	other Streamable
+	in    chan any
+	out   chan any
+	canceler
+}
+
+// TakeUntil returns a new operator that takes elements until the other stream emits.
+func TakeUntil(other Streamable) *TakeUntilImpl {
+	return NewTakeUntil(other)
+}
+
+// NewTakeUntil returns a new operator that takes elements until the other stream emits.
+func NewTakeUntil(other Streamable) *TakeUntilImpl {
+	t := &TakeUntilImpl{
+		other:    other,
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
+	}
+
+	go t.attach()
+
+	return t
+}
+
+// To streams data to the sink and waits for it to complete.
+func (t *TakeUntilImpl) To(sink Sinkable) error {
+	t.stream(sink)
+
+	err := sink.Wait()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// In returns the input channel.
+func (t *TakeUntilImpl) In() chan<- any {
+	return t.in
+}
+
+// Out returns the output channel.
+func (t *TakeUntilImpl) Out() <-chan any {
+	return t.out
+}
+
+// Pipe pipes the output channel to the input channel.
+func (t *TakeUntilImpl) Pipe(c Operatable) Operatable {
+	go t.stream(c)
+	return c
+}
+
+func (t *TakeUntilImpl) stream(r Receivable) {
+	done := doneOf(r)
+	for x := range t.out {
+		select {
+		case r.In() <- x:
+		case <-done:
+			t.cancel()
+		}
+	}
+
+	close(r.In())
+}
+
+func (t *TakeUntilImpl) attach() {
+	signal := t.other.Out()
+
+loop:
+	for {
+		select {
+		case x, ok := <-t.in:
+			if !ok {
+				break loop
+			}
+
+			t.out <- x
+		case _, ok := <-signal:
+			if ok {
+				break loop
+			}
+
+			signal = nil // the signal stream closed without emitting
+		}
+	}
+
+	drain(signal)
+
+	t.cancel()
+	close(t.out)
+}
@@ -0,0 +1,45 @@
+package streams_test
+
+import (
+	"testing"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTakeUntil(t *testing.T) {
+	signal := make(chan any)
+	recv := streams.TakeUntil(sources.NewChanSource(signal))
+
+	go func() {
+		recv.In() <- 1
+		recv.In() <- 2
+		signal <- struct{}{}
+
+		<-recv.Done()
+		signal <- struct{}{} // the signal stream is drained
+		close(signal)
+		close(recv.In())
+	}()
+
+	output := channels.Slice[int](recv.Out())
+	require.Equal(t, []int{1, 2}, output)
+}
+
+func TestTakeUntilClosedSignal(t *testing.T) {
+	signal := make(chan any)
+	close(signal)
+
+	recv := streams.TakeUntil(sources.NewChanSource(signal))
+
+	go func() {
+		recv.In() <- 1
+		recv.In() <- 2
+		close(recv.In())
+	}()
+
+	output := channels.Slice[int](recv.Out())
+	require.Equal(t, []int{1, 2}, output)
+}
@@ -0,0 +1,88 @@
+package streams
+
+var (
+	_ Streamable = (*TakeWhileImpl[any])(nil)
+	_ Receivable = (*TakeWhileImpl[any])(nil)
+	_ Cancelable = (*TakeWhileImpl[any])(nil)
+)
+
+// TakeWhileImpl takes elements as long as the predicate holds and cancels the upstream afterwards.
+type TakeWhileImpl[T any] struct {
+	fn  FilterPredicate[T]
+	in  chan any
+	out chan any
+	canceler
+}
+
+// TakeWhile returns a new operator that takes elements as long as the predicate holds.
+func TakeWhile[T any](fn FilterPredicate[T]) *TakeWhileImpl[T] {
+	return NewTakeWhile(fn)
+}
+
+// NewTakeWhile returns a new operator that takes elements as long as the predicate holds.
+func NewTakeWhile[T any](fn FilterPredicate[T]) *TakeWhileImpl[T] {
+	t := &TakeWhileImpl[T]{
+		fn:       fn,
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
+	}
+
+	go t.attach()
+
+	return t
+}
+
+// To streams data to the sink and waits for it to complete.
+func (t *TakeWhileImpl[T]) To(sink Sinkable) error {
+	t.stream(sink)
+
+	err := sink.Wait()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// In returns the input channel.
+func (t *TakeWhileImpl[T]) In() chan<- any {
+	return t.in
+}
+
+// Out returns the output channel.
+func (t *TakeWhileImpl[T]) Out() <-chan any {
+	return t.out
+}
+
+// Pipe pipes the output channel to the input channel.
+func (t *TakeWhileImpl[T]) Pipe(c Operatable) Operatable {
+	go t.stream(c)
+	return c
+}
+
+func (t *TakeWhileImpl[T]) stream(r Receivable) {
+	done := doneOf(r)
+	for x := range t.out {
+		select {
+		case r.In() <- x:
+		case <-done:
+			t.cancel()
+		}
+	}
+
+	close(r.In())
+}
+
+func (t *TakeWhileImpl[T]) attach() {
+	for x := range t.in {
+		if !t.fn(x.(T)) {
+			break
+		}
+
+		t.out <- x
+	}
+
+	t.cancel()
+	close(t.out)
+}
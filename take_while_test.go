@@ -0,0 +1,81 @@
+package streams_test
+
+import (
+	"testing"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/sinks"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/require"
+)
+
+func lessThan(n int) func(int) bool {
+	return func(x int) bool {
+		return x < n
+	}
+}
+
+func TestTakeWhile(t *testing.T) {
+	tests := []struct {
+		name     string
+		recv     streams.Operatable
+		in       []int
+		expected []int
+	}{
+		{
+			name:     "less than 3",
+			in:       []int{1, 2, 3, 1, 2},
+			expected: []int{1, 2},
+			recv:     streams.TakeWhile(lessThan(3)),
+		},
+		{
+			name:     "all",
+			in:       []int{1, 2, 3},
+			expected: []int{1, 2, 3},
+			recv:     streams.TakeWhile(lessThan(10)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := make(chan any, 5)
+			out := make(chan any, 5)
+
+			channels.Channel(tt.in, in)
+
+			source := sources.NewChanSource(in)
+			sink := sinks.NewChanSink(out)
+
+			close(in)
+
+			err := source.Pipe(tt.recv).To(sink)
+			require.NoError(t, err)
+
+			output := channels.Slice[int](out)
+			require.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func TestTakeWhileCancelsUpstream(t *testing.T) {
+	in := make(chan any)
+	out := make(chan any, 5)
+
+	go func() {
+		for i := 0; ; i++ {
+			in <- i
+		}
+	}()
+
+	m := streams.Map(func(x int) int { return x })
+	recv := streams.TakeWhile(lessThan(3))
+
+	err := sources.NewChanSource(in).Pipe(m).Pipe(recv).To(sinks.NewChanSink(out))
+	require.NoError(t, err)
+
+	require.Equal(t, []int{0, 1, 2}, channels.Slice[int](out))
+
+	<-recv.Done()
+	<-m.Done()
+}
@@ -0,0 +1,160 @@
+package streams
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+var _ Aggregator[any, []float64] = (*Quantiles[any])(nil)
+
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// Quantiles estimates quantiles of the values of elements with a t-digest.
+// The memory is bounded by the compression, larger values are more accurate.
+type Quantiles[T any] struct {
+	compression float64
+	value       func(T) float64
+	qs          []float64
+	centroids   []centroid
+	buffer      []centroid
+	count       float64
+	min         float64
+	max         float64
+}
+
+// NewQuantiles returns a new Quantiles that reports the given quantiles as result.
+func NewQuantiles[T any](compression float64, value func(T) float64, qs ...float64) *Quantiles[T] {
+	return &Quantiles[T]{
+		compression: max(compression, 20),
+		value:       value,
+		qs:          qs,
+		min:         math.Inf(1),
+		max:         math.Inf(-1),
+	}
+}
+
+// Add adds an element.
+func (t *Quantiles[T]) Add(x T) {
+	t.add(centroid{mean: t.value(x), weight: 1})
+}
+
+func (t *Quantiles[T]) add(c centroid) {
+	t.buffer = append(t.buffer, c)
+	t.count += c.weight
+	t.min = math.Min(t.min, c.mean)
+	t.max = math.Max(t.max, c.mean)
+
+	if len(t.buffer) >= int(5*t.compression) {
+		t.compress()
+	}
+}
+
+// Quantile returns the estimated value at quantile q between 0 and 1.
+// It returns NaN if no element has been added.
+func (t *Quantiles[T]) Quantile(q float64) float64 {
+	t.compress()
+
+	if len(t.centroids) == 0 {
+		return math.NaN()
+	}
+
+	switch {
+	case q <= 0:
+		return t.min
+	case q >= 1:
+		return t.max
+	case len(t.centroids) == 1:
+		return t.centroids[0].mean
+	}
+
+	target := q * t.count
+
+	var cum float64
+	for i, c := range t.centroids {
+		center := cum + c.weight/2
+		if target < center {
+			if i == 0 {
+				return t.min + (c.mean-t.min)*target/center
+			}
+
+			prev := t.centroids[i-1]
+			prevCenter := cum - prev.weight/2
+
+			return prev.mean + (c.mean-prev.mean)*(target-prevCenter)/(center-prevCenter)
+		}
+
+		cum += c.weight
+	}
+
+	last := t.centroids[len(t.centroids)-1]
+	lastCenter := t.count - last.weight/2
+
+	return last.mean + (t.max-last.mean)*(target-lastCenter)/(t.count-lastCenter)
+}
+
+// Result returns the estimated values of the configured quantiles.
+func (t *Quantiles[T]) Result() []float64 {
+	res := make([]float64, len(t.qs))
+	for i, q := range t.qs {
+		res[i] = t.Quantile(q)
+	}
+
+	return res
+}
+
+// Merge merges the state of another Quantiles into this one.
+func (t *Quantiles[T]) Merge(other *Quantiles[T]) error {
+	if t.compression != other.compression {
+		return ErrIncompatibleAggregate
+	}
+
+	for _, c := range slices.Concat(other.centroids, other.buffer) {
+		t.add(c)
+	}
+
+	// the centroid means lie within the extremes of the other side
+	t.min = math.Min(t.min, other.min)
+	t.max = math.Max(t.max, other.max)
+
+	return nil
+}
+
+// compress merges the buffered values into the centroids. Neighbouring centroids are
+// merged as long as their weight stays below 4*n*q*(1-q)/compression, so the centroids
+// at the tails stay small and the tails are estimated accurately.
+func (t *Quantiles[T]) compress() {
+	if len(t.buffer) == 0 {
+		return
+	}
+
+	all := slices.Concat(t.centroids, t.buffer)
+	sort.Slice(all, func(i, j int) bool { return all[i].mean < all[j].mean })
+
+	merged := make([]centroid, 0, len(t.centroids)+1)
+	cur := all[0]
+
+	var cum float64
+	for _, c := range all[1:] {
+		q0 := cum / t.count
+		q2 := (cum + cur.weight + c.weight) / t.count
+		limit := 4 * t.count * math.Min(q0*(1-q0), q2*(1-q2)) / t.compression
+
+		if cur.weight+c.weight <= limit {
+			cur.mean += (c.mean - cur.mean) * c.weight / (cur.weight + c.weight)
+			cur.weight += c.weight
+
+			continue
+		}
+
+		cum += cur.weight
+		merged = append(merged, cur)
+		cur = c
+	}
+
+	t.centroids = append(merged, cur)
+	t.buffer = t.buffer[:0]
+}
@@ -0,0 +1,48 @@
+package streams_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/katallaxie/streams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func identity(x float64) float64 {
+	return x
+}
+
+func TestQuantiles(t *testing.T) {
+	q := streams.NewQuantiles(100, identity, 0.5, 0.99)
+	assert.True(t, math.IsNaN(q.Quantile(0.5)))
+
+	for _, i := range rand.New(rand.NewPCG(1, 2)).Perm(10000) {
+		q.Add(float64(i + 1))
+	}
+
+	res := q.Result()
+	require.Len(t, res, 2)
+	assert.InEpsilon(t, 5000, res[0], 0.01)
+	assert.InEpsilon(t, 9900, res[1], 0.01)
+	assert.InDelta(t, 1, q.Quantile(0), 0)
+	assert.InDelta(t, 10000, q.Quantile(1), 0)
+}
+
+func TestQuantilesMerge(t *testing.T) {
+	a := streams.NewQuantiles(100, identity)
+	b := streams.NewQuantiles(100, identity)
+
+	for i := range 5000 {
+		a.Add(float64(i + 1))
+		b.Add(float64(i + 5001))
+	}
+
+	require.NoError(t, a.Merge(b))
+	assert.InEpsilon(t, 5000, a.Quantile(0.5), 0.01)
+	assert.InDelta(t, 1, a.Quantile(0), 0)
+	assert.InDelta(t, 10000, a.Quantile(1), 0)
+
+	require.ErrorIs(t, a.Merge(streams.NewQuantiles(200, identity)), streams.ErrIncompatibleAggregate)
+}
@@ -14,6 +14,7 @@ type TimeoutImpl struct {
 	dur time.Duration
 	in  chan any
 	out chan any
+	canceler
 }
 
 // Timeout returns a new timeout pipe.
@@ -24,9 +25,10 @@ func Timeout(dur time.Duration) *TimeoutImpl {
 // NewTimeout creates a new Timeout operator.
 func NewTimeout(dur time.Duration) *TimeoutImpl {
 	t := &TimeoutImpl{
-		dur: dur,
-		in:  make(chan any),
-		out: make(chan any),
+		dur:      dur,
+		in:       make(chan any),
+		out:      make(chan any),
+		canceler: newCanceler(),
 	}
 
 	go t.attach()
@@ -63,6 +65,7 @@ func (t *TimeoutImpl) Pipe(c Operatable) Operatable {
 }
 
 func (t *TimeoutImpl) stream(r Receivable) {
+	done := doneOf(r)
 	elapsed := time.After(t.dur)
 	defer close(t.out)
 
@@ -74,7 +77,11 @@ OUTTER:
 				break OUTTER
 			}
 
-			r.In() <- v
+			select {
+			case r.In() <- v:
+			case <-done:
+				t.cancel()
+			}
 
 		case <-elapsed:
 			break OUTTER
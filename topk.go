@@ -0,0 +1,177 @@
+package streams
+
+import (
+	"container/heap"
+	"errors"
+	"sort"
+)
+
+// ErrIncompatibleAggregate is returned when aggregates with different parameters are merged.
+var ErrIncompatibleAggregate = errors.New("streams: incompatible aggregates")
+
+// TopKItem is an estimated frequent key.
+type TopKItem struct {
+	// Key is the key of the elements.
+	Key string
+	// Count is the estimated number of elements, it overestimates by at most Err.
+	Count uint64
+	// Err is the maximum overestimation of the count.
+	Err uint64
+}
+
+var _ Aggregator[any, []TopKItem] = (*TopK[any])(nil)
+
+// TopK estimates the k most frequent keys with the space-saving algorithm.
+// It monitors a fixed number of keys, so the memory does not grow with the number of distinct keys.
+type TopK[T any] struct {
+	k        int
+	key      KeyFunc[T]
+	capacity int
+	items    map[string]*topKCounter
+	heap     topKHeap
+}
+
+type topKCounter struct {
+	TopKItem
+	index int
+}
+
+// NewTopK returns a new TopK that monitors ten times k keys.
+func NewTopK[T any](k int, key KeyFunc[T]) *TopK[T] {
+	return NewTopKWithCapacity(k, 10*k, key)
+}
+
+// NewTopKWithCapacity returns a new TopK that monitors the given number of keys.
+// A larger capacity improves the accuracy of the estimates.
+func NewTopKWithCapacity[T any](k, capacity int, key KeyFunc[T]) *TopK[T] {
+	return &TopK[T]{
+		k:        k,
+		key:      key,
+		capacity: max(capacity, k, 1),
+		items:    make(map[string]*topKCounter),
+	}
+}
+
+// Add adds an element.
+func (t *TopK[T]) Add(x T) {
+	t.add(t.key(x), 1, 0)
+}
+
+func (t *TopK[T]) add(key string, count, errs uint64) {
+	if c, ok := t.items[key]; ok {
+		c.Count += count
+		c.Err += errs
+		heap.Fix(&t.heap, c.index)
+
+		return
+	}
+
+	if len(t.items) < t.capacity {
+		c := &topKCounter{TopKItem: TopKItem{Key: key, Count: count, Err: errs}}
+		t.items[key] = c
+		heap.Push(&t.heap, c)
+
+		return
+	}
+
+	// replace the key with the minimum count, which bounds the error of the new key
+	c := t.heap[0]
+	delete(t.items, c.Key)
+
+	c.Err = c.Count + errs
+	c.Count += count
+	c.Key = key
+	t.items[key] = c
+	heap.Fix(&t.heap, 0)
+}
+
+// Result returns the k most frequent keys in descending order of their count.
+func (t *TopK[T]) Result() []TopKItem {
+	items := make([]TopKItem, 0, len(t.items))
+	for _, c := range t.items {
+		items = append(items, c.TopKItem)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Count == items[j].Count {
+			return items[i].Key < items[j].Key
+		}
+
+		return items[i].Count > items[j].Count
+	})
+
+	return items[:min(t.k, len(items))]
+}
+
+// Merge merges the state of another TopK into this one.
+func (t *TopK[T]) Merge(other *TopK[T]) error {
+	if t.capacity != other.capacity {
+		return ErrIncompatibleAggregate
+	}
+
+	// keys that are not monitored by one side may have a count up to its minimum
+	var minT, minO uint64
+	if len(t.items) == t.capacity {
+		minT = t.heap[0].Count
+	}
+	if len(other.items) == other.capacity {
+		minO = other.heap[0].Count
+	}
+
+	merged := NewTopKWithCapacity(t.k, t.capacity, t.key)
+	counts := make(map[string]TopKItem, len(t.items)+len(other.items))
+
+	for key, c := range t.items {
+		counts[key] = TopKItem{Key: key, Count: c.Count + minO, Err: c.Err + minO}
+	}
+
+	for key, c := range other.items {
+		if m, ok := counts[key]; ok {
+			counts[key] = TopKItem{Key: key, Count: m.Count - minO + c.Count, Err: m.Err - minO + c.Err}
+			continue
+		}
+
+		counts[key] = TopKItem{Key: key, Count: c.Count + minT, Err: c.Err + minT}
+	}
+
+	items := make([]TopKItem, 0, len(counts))
+	for _, c := range counts {
+		items = append(items, c)
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].Count > items[j].Count })
+
+	for _, c := range items[:min(t.capacity, len(items))] {
+		merged.add(c.Key, c.Count, c.Err)
+	}
+
+	t.items = merged.items
+	t.heap = merged.heap
+
+	return nil
+}
+
+type topKHeap []*topKCounter
+
+func (h topKHeap) Len() int           { return len(h) }
+func (h topKHeap) Less(i, j int) bool { return h[i].Count < h[j].Count }
+
+func (h topKHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *topKHeap) Push(x any) {
+	c := x.(*topKCounter)
+	c.index = len(*h)
+	*h = append(*h, c)
+}
+
+func (h *topKHeap) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return c
+}
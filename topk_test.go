@@ -0,0 +1,43 @@
+package streams_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/katallaxie/streams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopK(t *testing.T) {
+	topk := streams.NewTopKWithCapacity(2, 3, strconv.Itoa)
+
+	for _, x := range []int{1, 1, 1, 2, 2, 3, 4, 1, 2, 5} {
+		topk.Add(x)
+	}
+
+	res := topk.Result()
+	require.Len(t, res, 2)
+	assert.Equal(t, "1", res[0].Key)
+	assert.GreaterOrEqual(t, res[0].Count, uint64(4))
+	assert.Equal(t, "2", res[1].Key)
+	assert.GreaterOrEqual(t, res[1].Count, uint64(3))
+}
+
+func TestTopKMerge(t *testing.T) {
+	a := streams.NewTopK(2, strconv.Itoa)
+	b := streams.NewTopK(2, strconv.Itoa)
+
+	for _, x := range []int{1, 1, 2} {
+		a.Add(x)
+	}
+
+	for _, x := range []int{2, 2, 3} {
+		b.Add(x)
+	}
+
+	require.NoError(t, a.Merge(b))
+	assert.Equal(t, []streams.TopKItem{{Key: "2", Count: 3}, {Key: "1", Count: 2}}, a.Result())
+
+	require.ErrorIs(t, a.Merge(streams.NewTopK(3, strconv.Itoa)), streams.ErrIncompatibleAggregate)
+}
@@ -0,0 +1,31 @@
+package streams
+
+// Pair is a pair of two elements.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Unzip splits a stream of pairs into a stream of the first and a stream of the second elements.
+// Elements that are not a Pair[A, B] are dropped.
+func Unzip[A, B any](in Streamable) [2]Operatable {
+	first := PassThrough()
+	second := PassThrough()
+
+	go func() {
+		for x := range in.Out() {
+			p, ok := x.(Pair[A, B])
+			if !ok {
+				continue
+			}
+
+			first.In() <- p.First
+			second.In() <- p.Second
+		}
+
+		close(first.In())
+		close(second.In())
+	}()
+
+	return [...]Operatable{first, second}
+}
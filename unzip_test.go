@@ -0,0 +1,27 @@
+package streams_test
+
+import (
+	"testing"
+
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams"
+	"github.com/katallaxie/streams/sources"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnzip(t *testing.T) {
+	in := make(chan any, 4)
+	channels.Channel([]any{
+		streams.Pair[string, int]{"a", 1},
+		streams.Pair[string, int]{"b", 2},
+		"c",
+		streams.Pair[string, int]{"d", 4},
+	}, in)
+	close(in)
+
+	outputs := streams.Unzip[string, int](sources.NewChanSource(in))
+	res := collect(t, map[string]streams.Operatable{"first": outputs[0], "second": outputs[1]})
+
+	require.Equal(t, []any{"a", "b", "d"}, res["first"])
+	require.Equal(t, []any{1, 2, 4}, res["second"])
+}
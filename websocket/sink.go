@@ -0,0 +1,265 @@
+package websocket
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	gorilla "github.com/gorilla/websocket"
+	"github.com/katallaxie/streams"
+)
+
+var (
+	_ streams.Sinkable = (*Sink)(nil)
+	_ http.Handler     = (*Sink)(nil)
+)
+
+// SinkConfig holds the configuration for a WebSocket sink.
+type SinkConfig struct {
+	// Upgrader upgrades the HTTP connections.
+	Upgrader *gorilla.Upgrader
+	// ClientBuffer is the number of messages that are buffered per client.
+	ClientBuffer int
+	// PingInterval is the interval to send pings to the clients, zero disables it.
+	PingInterval time.Duration
+	// PongTimeout is the time to wait for a pong before the client is disconnected.
+	PongTimeout time.Duration
+	// WriteTimeout is the deadline of a write, a client that exceeds it is disconnected.
+	WriteTimeout time.Duration
+}
+
+// DefaultSinkConfig returns a default WebSocket sink configuration.
+func DefaultSinkConfig() *SinkConfig {
+	return &SinkConfig{
+		Upgrader:     &gorilla.Upgrader{},
+		ClientBuffer: 64,
+		PingInterval: 30 * time.Second,
+		PongTimeout:  60 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+}
+
+type client struct {
+	send chan Message
+	gone chan struct{}
+	once sync.Once
+}
+
+func (c *client) disconnect() {
+	c.once.Do(func() {
+		close(c.gone)
+	})
+}
+
+// Sink is an http.Handler that broadcasts the elements to every connected WebSocket client.
+// Byte slices are sent as binary messages, strings as text messages and other elements as JSON.
+// Slow clients apply backpressure to the input channel until they exceed the write timeout.
+type Sink struct {
+	cfg     *SinkConfig
+	in      chan any
+	done    chan struct{}
+	mu      sync.Mutex
+	clients map[*client]struct{}
+	closed  bool
+}
+
+// NewSink returns a new WebSocket sink.
+// A nil upgrader and a non-positive client buffer are replaced by the defaults.
+func NewSink(cfg *SinkConfig) *Sink {
+	defaults := DefaultSinkConfig()
+	if cfg == nil {
+		cfg = defaults
+	}
+
+	c := *cfg
+	if c.Upgrader == nil {
+		c.Upgrader = defaults.Upgrader
+	}
+
+	if c.ClientBuffer <= 0 {
+		c.ClientBuffer = defaults.ClientBuffer
+	}
+
+	s := &Sink{
+		cfg:     &c,
+		in:      make(chan any),
+		done:    make(chan struct{}),
+		clients: make(map[*client]struct{}),
+	}
+
+	go s.attach()
+
+	return s
+}
+
+// In returns the input channel.
+func (s *Sink) In() chan<- any {
+	return s.in
+}
+
+// Wait waits for the sink to complete.
+func (s *Sink) Wait() error {
+	<-s.done
+
+	return nil
+}
+
+// Clients returns the number of connected clients.
+func (s *Sink) Clients() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.clients)
+}
+
+func (s *Sink) attach() {
+	defer close(s.done)
+
+	for x := range s.in {
+		msg, err := encode(x)
+		if err != nil {
+			continue
+		}
+
+		s.mu.Lock()
+		clients := make([]*client, 0, len(s.clients))
+		for c := range s.clients {
+			clients = append(clients, c)
+		}
+		s.mu.Unlock()
+
+		for _, c := range clients {
+			select {
+			case c.send <- msg:
+			case <-c.gone:
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for c := range s.clients {
+		close(c.send)
+	}
+}
+
+// ServeHTTP upgrades the connection and streams the elements to the client.
+func (s *Sink) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		http.Error(w, http.StatusText(http.StatusGone), http.StatusGone)
+
+		return
+	}
+	s.mu.Unlock()
+
+	conn, err := s.cfg.Upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return // the upgrader replied with an error
+	}
+	defer conn.Close()
+
+	c := &client{
+		send: make(chan Message, s.cfg.ClientBuffer),
+		gone: make(chan struct{}),
+	}
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		_ = conn.WriteControl(gorilla.CloseMessage, gorilla.FormatCloseMessage(gorilla.CloseNormalClosure, ""), time.Now().Add(time.Second))
+
+		return
+	}
+	s.clients[c] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, c)
+		s.mu.Unlock()
+		c.disconnect()
+	}()
+
+	go s.read(conn, c)
+	s.write(conn, c)
+}
+
+// read processes the control messages of the client and discards its data messages.
+func (s *Sink) read(conn *gorilla.Conn, c *client) {
+	defer c.disconnect()
+
+	if s.cfg.PongTimeout > 0 {
+		_ = conn.SetReadDeadline(time.Now().Add(s.cfg.PongTimeout))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(s.cfg.PongTimeout))
+		})
+	}
+
+	for {
+		if _, _, err := conn.NextReader(); err != nil {
+			return
+		}
+	}
+}
+
+func (s *Sink) write(conn *gorilla.Conn, c *client) {
+	var ping <-chan time.Time
+	if s.cfg.PingInterval > 0 {
+		ticker := time.NewTicker(s.cfg.PingInterval)
+		defer ticker.Stop()
+		ping = ticker.C
+	}
+
+	deadline := func() time.Time {
+		if s.cfg.WriteTimeout > 0 {
+			return time.Now().Add(s.cfg.WriteTimeout)
+		}
+
+		return time.Time{}
+	}
+
+	for {
+		select {
+		case msg, ok := <-c.send:
+			_ = conn.SetWriteDeadline(deadline())
+
+			if !ok {
+				_ = conn.WriteMessage(gorilla.CloseMessage, gorilla.FormatCloseMessage(gorilla.CloseNormalClosure, ""))
+				return
+			}
+
+			if err := conn.WriteMessage(int(msg.Type), msg.Data); err != nil {
+				return
+			}
+		case <-ping:
+			if err := conn.WriteControl(gorilla.PingMessage, nil, deadline()); err != nil {
+				return
+			}
+		case <-c.gone:
+			return
+		}
+	}
+}
+
+func encode(x any) (Message, error) {
+	switch msg := x.(type) {
+	case Message:
+		return msg, nil
+	case []byte:
+		return Message{Type: BinaryMessage, Data: msg}, nil
+	case string:
+		return Message{Type: TextMessage, Data: []byte(msg)}, nil
+	default:
+		b, err := json.Marshal(msg)
+		if err != nil {
+			return Message{}, err
+		}
+
+		return Message{Type: TextMessage, Data: b}, nil
+	}
+}
@@ -0,0 +1,233 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	gorilla "github.com/gorilla/websocket"
+	"github.com/katallaxie/streams"
+)
+
+var _ streams.Sourceable = (*Source)(nil)
+
+// MessageType is the type of a WebSocket message.
+type MessageType int
+
+const (
+	// TextMessage is a UTF-8 encoded text message.
+	TextMessage MessageType = gorilla.TextMessage
+	// BinaryMessage is a binary message.
+	BinaryMessage MessageType = gorilla.BinaryMessage
+)
+
+// Message is a WebSocket message.
+type Message struct {
+	// Type is the type of the message.
+	Type MessageType
+	// Data is the payload of the message.
+	Data []byte
+}
+
+// SourceConfig holds the configuration for a WebSocket source.
+type SourceConfig struct {
+	// URL is the URL of the WebSocket server, e.g. "wss://example.com/feed".
+	URL string
+	// Header is added to the handshake request.
+	Header http.Header
+	// Dialer is the dialer to connect to the server.
+	Dialer *gorilla.Dialer
+	// Reconnect is the policy to reconnect to the server.
+	// MaxAttempts is the number of consecutive failed connections, it is unlimited if zero.
+	Reconnect *streams.RetryPolicy
+	// PingInterval is the interval to send pings to the server, zero disables it.
+	PingInterval time.Duration
+	// PongTimeout is the time to wait for a pong or a message before the connection is considered dead.
+	PongTimeout time.Duration
+	// ReadLimit is the maximum size of a message in bytes, there is no limit if zero.
+	ReadLimit int64
+}
+
+// DefaultSourceConfig returns a default WebSocket source configuration.
+func DefaultSourceConfig() *SourceConfig {
+	return &SourceConfig{
+		Dialer: gorilla.DefaultDialer,
+		Reconnect: &streams.RetryPolicy{
+			InitialBackoff: time.Second,
+			MaxBackoff:     30 * time.Second,
+			Multiplier:     2,
+			Jitter:         0.2,
+		},
+		PingInterval: 30 * time.Second,
+		PongTimeout:  60 * time.Second,
+		ReadLimit:    1 << 20,
+	}
+}
+
+// Source is a source that emits each message of a WebSocket connection as Message.
+// It reconnects with backoff until the server closes the connection with a normal closure.
+type Source struct {
+	cfg     *SourceConfig
+	out     chan any
+	err     error
+	errOnce sync.Once
+}
+
+// NewSource returns a new WebSocket source.
+// A nil dialer or reconnect policy is replaced by the default.
+func NewSource(ctx context.Context, cfg *SourceConfig) (*Source, error) {
+	defaults := DefaultSourceConfig()
+	if cfg == nil {
+		cfg = defaults
+	}
+
+	c := *cfg
+	if c.Dialer == nil {
+		c.Dialer = defaults.Dialer
+	}
+
+	if c.Reconnect == nil {
+		c.Reconnect = defaults.Reconnect
+	}
+
+	s := &Source{
+		cfg: &c,
+		out: make(chan any),
+	}
+
+	go s.attach(ctx)
+
+	return s, nil
+}
+
+// Error returns the error.
+func (s *Source) Error() error {
+	return s.err
+}
+
+func (s *Source) fail(err error) {
+	s.errOnce.Do(func() {
+		s.err = err
+	})
+}
+
+// Pipe pipes the output channel to the input channel.
+func (s *Source) Pipe(operator streams.Operatable) streams.Operatable {
+	streams.Pipe(s, operator)
+	return operator
+}
+
+// Out returns the output channel.
+func (s *Source) Out() <-chan any {
+	return s.out
+}
+
+func (s *Source) attach(ctx context.Context) {
+	defer close(s.out)
+
+	policy := s.cfg.Reconnect
+	attempt := 0
+
+	for {
+		received, err := s.connect(ctx)
+		if ctx.Err() != nil || gorilla.IsCloseError(err, gorilla.CloseNormalClosure) {
+			return
+		}
+
+		if received {
+			attempt = 0
+		}
+		attempt++
+
+		if (policy.Retryable != nil && !policy.Retryable(err)) || (policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts) {
+			s.fail(&streams.RetryError{Attempts: attempt, Err: err})
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(policy.Backoff(attempt)):
+		}
+	}
+}
+
+// connect reads the messages until the connection fails and returns if any messages have been received.
+func (s *Source) connect(ctx context.Context) (bool, error) {
+	conn, res, err := s.cfg.Dialer.DialContext(ctx, s.cfg.URL, s.cfg.Header)
+	if res != nil && res.Body != nil {
+		_ = res.Body.Close()
+	}
+
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+	defer stop()
+
+	if s.cfg.ReadLimit > 0 {
+		conn.SetReadLimit(s.cfg.ReadLimit)
+	}
+
+	if s.cfg.PongTimeout > 0 {
+		_ = conn.SetReadDeadline(time.Now().Add(s.cfg.PongTimeout))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(s.cfg.PongTimeout))
+		})
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	if s.cfg.PingInterval > 0 {
+		go ping(conn, s.cfg.PingInterval, done)
+	}
+
+	received := false
+	for {
+		typ, data, err := conn.ReadMessage()
+		if err != nil {
+			return received, err
+		}
+
+		if s.cfg.PongTimeout > 0 {
+			_ = conn.SetReadDeadline(time.Now().Add(s.cfg.PongTimeout))
+		}
+
+		select {
+		case s.out <- Message{Type: MessageType(typ), Data: data}:
+			received = true
+		case <-ctx.Done():
+			return received, ctx.Err()
+		}
+	}
+}
+
+// ping sends pings until done is closed or a ping fails.
+func ping(conn *gorilla.Conn, interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			err := conn.WriteControl(gorilla.PingMessage, nil, time.Now().Add(interval))
+			if errors.Is(err, gorilla.ErrCloseSent) {
+				return
+			}
+
+			if err != nil {
+				_ = conn.Close()
+				return
+			}
+		}
+	}
+}
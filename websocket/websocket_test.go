@@ -0,0 +1,124 @@
+package websocket_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gorilla "github.com/gorilla/websocket"
+	"github.com/katallaxie/pkg/channels"
+	"github.com/katallaxie/streams/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func sourceConfig(url string) *websocket.SourceConfig {
+	cfg := websocket.DefaultSourceConfig()
+	cfg.URL = "ws" + strings.TrimPrefix(url, "http")
+	cfg.Reconnect.InitialBackoff = time.Millisecond
+	cfg.PingInterval = 10 * time.Millisecond
+
+	return cfg
+}
+
+func TestSink(t *testing.T) {
+	sink := websocket.NewSink(nil)
+
+	srv := httptest.NewServer(sink)
+	defer srv.Close()
+
+	src, err := websocket.NewSource(context.Background(), sourceConfig(srv.URL))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return sink.Clients() == 1 }, 5*time.Second, time.Millisecond)
+
+	sink.In() <- "foo"
+	sink.In() <- []byte("bar")
+	sink.In() <- map[string]int{"baz": 1}
+	close(sink.In())
+	require.NoError(t, sink.Wait())
+
+	messages := channels.Slice[any](src.Out())
+	require.NoError(t, src.Error())
+	require.Equal(t, []any{
+		websocket.Message{Type: websocket.TextMessage, Data: []byte("foo")},
+		websocket.Message{Type: websocket.BinaryMessage, Data: []byte("bar")},
+		websocket.Message{Type: websocket.TextMessage, Data: []byte(`{"baz":1}`)},
+	}, messages)
+}
+
+func TestSource_Reconnect(t *testing.T) {
+	var conns atomic.Int32
+	upgrader := gorilla.Upgrader{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		if conns.Add(1) == 1 {
+			_ = conn.WriteMessage(gorilla.TextMessage, []byte("foo"))
+			return // drop the connection without a close message
+		}
+
+		_ = conn.WriteMessage(gorilla.TextMessage, []byte("bar"))
+		_ = conn.WriteMessage(gorilla.CloseMessage, gorilla.FormatCloseMessage(gorilla.CloseNormalClosure, ""))
+		_, _, _ = conn.ReadMessage()
+	}))
+	defer srv.Close()
+
+	src, err := websocket.NewSource(context.Background(), sourceConfig(srv.URL))
+	require.NoError(t, err)
+
+	messages := channels.Slice[any](src.Out())
+	require.NoError(t, src.Error())
+	require.Equal(t, []any{
+		websocket.Message{Type: websocket.TextMessage, Data: []byte("foo")},
+		websocket.Message{Type: websocket.TextMessage, Data: []byte("bar")},
+	}, messages)
+	require.Equal(t, int32(2), conns.Load())
+}
+
+func TestSource_Cancel(t *testing.T) {
+	sink := websocket.NewSink(nil)
+
+	srv := httptest.NewServer(sink)
+	defer srv.Close()
+	defer close(sink.In())
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	src, err := websocket.NewSource(ctx, sourceConfig(srv.URL))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return sink.Clients() == 1 }, 5*time.Second, time.Millisecond)
+	cancel()
+
+	require.Empty(t, channels.Slice[any](src.Out()))
+	require.NoError(t, src.Error())
+	require.Eventually(t, func() bool { return sink.Clients() == 0 }, 5*time.Second, time.Millisecond)
+}
+
+func TestZeroConfig(t *testing.T) {
+	sink := websocket.NewSink(&websocket.SinkConfig{})
+
+	srv := httptest.NewServer(sink)
+	defer srv.Close()
+
+	src, err := websocket.NewSource(context.Background(), &websocket.SourceConfig{URL: sourceConfig(srv.URL).URL})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return sink.Clients() == 1 }, 5*time.Second, time.Millisecond)
+
+	sink.In() <- "foo"
+	close(sink.In())
+	require.NoError(t, sink.Wait())
+
+	require.Equal(t, []any{websocket.Message{Type: websocket.TextMessage, Data: []byte("foo")}}, channels.Slice[any](src.Out()))
+	require.NoError(t, src.Error())
+}